@@ -1,105 +1,68 @@
 package cadastro_anexo_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CADASTRO_ANEXO_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodAnexo *int `json:"CodAnexo,omitempty"` // *Código do anexo.
+	CodAnexo *int `json:"CodAnexo,omitempty" validate:"required"` // *Código do anexo.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +90,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -150,63 +117,42 @@ type RequestResponseBody struct {
 	CodOrigem     *int                          `json:"CodOrigem,omitempty"`     // Código do cadastro de origem vinculado ao anexo.
 	SubCodOrigem  *string                       `json:"SubCodOrigem,omitempty"`  // Subcódigo do cadastro de origem vinculado ao anexo.
 	Descricao     *string                       `json:"Descricao,omitempty"`     // Descrição do Anexo.
-	DataAlteracao *string                       `json:"DataAlteracao,omitempty"` // Data da última alteração do anexo.
-	EnviaSite     *string                       `json:"EnviaSite,omitempty"`     // Habilitado para enviar para o site.
-	DataEnviaSite *string                       `json:"DataEnviaSite,omitempty"` // Data prevista para enviar para o site.
+	DataAlteracao *imobdate.Date                `json:"DataAlteracao,omitempty"` // Data da última alteração do anexo.
+	EnviaSite     *enums.Flag                   `json:"EnviaSite,omitempty"`     // Habilitado para enviar para o site.
+	DataEnviaSite *imobdate.Date                `json:"DataEnviaSite,omitempty"` // Data prevista para enviar para o site.
 	TotalArquivos *int                          `json:"TotalArquivos,omitempty"` // Total de arquivos encontrados na consulta.
 	Arquivos      *[]RequestResponseBodyArquivo `json:"Arquivos,omitempty"`      //
 }
 
 type RequestResponseBodyArquivo struct {
-	ArquivoNome     *string `json:"ArquivoNome,omitempty"`     // Nome do arquivo.
-	ArquivoTamanho  *string `json:"ArquivoTamanho,omitempty"`  // Tamanho do arquivo em bytes.
-	ArquivoDataHora *string `json:"ArquivoDataHora,omitempty"` // Data e hora da última modificação do arquivo no formato: AAAA-MM-DD-hh-mm-ss.
-	URL             *string `json:"URL,omitempty"`             // URL para download do arquivo.
+	ArquivoNome     *string            `json:"ArquivoNome,omitempty"`     // Nome do arquivo.
+	ArquivoTamanho  *string            `json:"ArquivoTamanho,omitempty"`  // Tamanho do arquivo em bytes.
+	ArquivoDataHora *imobdate.DateTime `json:"ArquivoDataHora,omitempty"` // Data e hora da última modificação do arquivo no formato: AAAA-MM-DD-hh-mm-ss.
+	URL             *string            `json:"URL,omitempty"`             // URL para download do arquivo.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
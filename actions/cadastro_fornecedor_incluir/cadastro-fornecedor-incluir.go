@@ -1,139 +1,102 @@
 package cadastro_fornecedor_incluir
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CADASTRO_FORNECEDOR_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	Nome                *string `json:"Nome,omitempty"`                // Nome/Razão Social do fornecedor.
-	NomeFantasia        *string `json:"NomeFantasia,omitempty"`        // Nome de fantasia do fornecedor.
-	TipoPessoa          *string `json:"TipoPessoa,omitempty"`          // *Tipo de pessoa do fornecedor.
-	CpfCnpj             *int    `json:"CpfCnpj,omitempty"`             // *Se for tipo de pessoa física preencher com o CPF. Se for tipo de pessoa jurídica preencher com o CNPJ. Se o tipo de pessoa não for informado então este campo deve ser vazio.
-	InscricaoInss       *string `json:"InscricaoInss,omitempty"`       // CPF/CNPJ do fornecedor.
-	InscricaoMunicipal  *string `json:"InscricaoMunicipal,omitempty"`  // Inscrição municipal do fornecedor.
-	Categoria           *string `json:"Categoria,omitempty"`           // *Categoria do fornecedor.
-	PIS                 *string `json:"PIS,omitempty"`                 // PIS do fornecedor.
-	TipoConta           *string `json:"TipoConta,omitempty"`           // *Tipo da conta bancária do fornecedor.
-	CodBanco            *int    `json:"CodBanco,omitempty"`            // *Código do banco.
-	CodAgencia          *int    `json:"CodAgencia,omitempty"`          // *Código da agência bancária.
-	ContaCorrente       *string `json:"ContaCorrente,omitempty"`       // *Número da conta corrente do fornecedor.
-	Contato             *string `json:"Contato,omitempty"`             // Contato no fornecedor.
-	CargoContato        *string `json:"CargoContato,omitempty"`        // Cargo do contato no fornecedor.
-	CEP                 *int    `json:"CEP,omitempty"`                 // *Número do CEP.
-	TipoLograd          *string `json:"TipoLograd,omitempty"`          // *Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
-	Logradouro          *string `json:"Logradouro,omitempty"`          // *Logradouro do endereço. Deve ser informado apenas o nome sem o tipo de logradouro.
-	Numero              *int    `json:"Numero,omitempty"`              // *Número do endereço.
-	Complemento         *string `json:"Complemento,omitempty"`         // Complemento do endereço.
-	Bairro              *string `json:"Bairro,omitempty"`              // *Bairro do endereço.
-	Cidade              *string `json:"Cidade,omitempty"`              // *Cidade do endereço.
-	UF                  *string `json:"UF,omitempty"`                  // *Sigla da Unidade Federativa do endereço.
-	Telefone1           *string `json:"Telefone1,omitempty"`           // Número do telefone principal.
-	Celular             *string `json:"Celular,omitempty"`             // Número do celular do fornecedor.
-	Email               *string `json:"Email,omitempty"`               // E-mail do fornecedor.
-	FormaPagamento      *string `json:"FormaPagamento,omitempty"`      // *Forma de pagamento do fornecedor.
-	TipoDocumento       *string `json:"TipoDocumento,omitempty"`       // *Tipos de documentos.
-	EmiteNFSE           *string `json:"EmiteNFSE,omitempty"`           // Indica se fornecedor emite NFSe. Valor default é 'S'.
-	Ativo               *string `json:"Ativo,omitempty"`               // Indica se está ativo. Valor default é 'S'.
-	CodPessoaFavorecido *int    `json:"CodPessoaFavorecido,omitempty"` // Código da pessoa favorecida em pagamentos ao fornecedor.
-	CodPessoaTitular    *int    `json:"CodPessoaTitular,omitempty"`    // Código da pessoa titular da empresa para fins previdenciários.
-	MEI                 *string `json:"MEI,omitempty"`                 // MEI do fornecedor.
-	NIT                 *string `json:"NIT,omitempty"`                 // NIT do fornecedor.
-	ProdutorRural       *string `json:"ProdutorRural,omitempty"`       // Indica se o fornecedor é produtor rural.
-	CodigoCBO           *string `json:"CodigoCBO,omitempty"`           // Código CBO (Classificação Brasileira de Ocupações).
+	Nome                *string               `json:"Nome,omitempty"`                               // Nome/Razão Social do fornecedor.
+	NomeFantasia        *string               `json:"NomeFantasia,omitempty"`                       // Nome de fantasia do fornecedor.
+	TipoPessoa          *enums.TipoPessoa     `json:"TipoPessoa,omitempty" validate:"required"`     // *Tipo de pessoa do fornecedor.
+	CpfCnpj             *doc.CPFCNPJ          `json:"CpfCnpj,omitempty" validate:"required"`        // *Se for tipo de pessoa física preencher com o CPF. Se for tipo de pessoa jurídica preencher com o CNPJ. Se o tipo de pessoa não for informado então este campo deve ser vazio.
+	InscricaoInss       *string               `json:"InscricaoInss,omitempty"`                      // CPF/CNPJ do fornecedor.
+	InscricaoMunicipal  *string               `json:"InscricaoMunicipal,omitempty"`                 // Inscrição municipal do fornecedor.
+	Categoria           *string               `json:"Categoria,omitempty" validate:"required"`      // *Categoria do fornecedor.
+	PIS                 *string               `json:"PIS,omitempty"`                                // PIS do fornecedor.
+	TipoConta           *string               `json:"TipoConta,omitempty" validate:"required"`      // *Tipo da conta bancária do fornecedor.
+	CodBanco            *int                  `json:"CodBanco,omitempty" validate:"required"`       // *Código do banco.
+	CodAgencia          *int                  `json:"CodAgencia,omitempty" validate:"required"`     // *Código da agência bancária.
+	ContaCorrente       *string               `json:"ContaCorrente,omitempty" validate:"required"`  // *Número da conta corrente do fornecedor.
+	Contato             *string               `json:"Contato,omitempty"`                            // Contato no fornecedor.
+	CargoContato        *string               `json:"CargoContato,omitempty"`                       // Cargo do contato no fornecedor.
+	CEP                 *int                  `json:"CEP,omitempty" validate:"required"`            // *Número do CEP.
+	TipoLograd          *string               `json:"TipoLograd,omitempty" validate:"required"`     // *Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
+	Logradouro          *string               `json:"Logradouro,omitempty" validate:"required"`     // *Logradouro do endereço. Deve ser informado apenas o nome sem o tipo de logradouro.
+	Numero              *int                  `json:"Numero,omitempty" validate:"required"`         // *Número do endereço.
+	Complemento         *string               `json:"Complemento,omitempty"`                        // Complemento do endereço.
+	Bairro              *string               `json:"Bairro,omitempty" validate:"required"`         // *Bairro do endereço.
+	Cidade              *string               `json:"Cidade,omitempty" validate:"required"`         // *Cidade do endereço.
+	UF                  *string               `json:"UF,omitempty" validate:"required"`             // *Sigla da Unidade Federativa do endereço.
+	Telefone1           *string               `json:"Telefone1,omitempty"`                          // Número do telefone principal.
+	Celular             *string               `json:"Celular,omitempty"`                            // Número do celular do fornecedor.
+	Email               *string               `json:"Email,omitempty"`                              // E-mail do fornecedor.
+	FormaPagamento      *enums.FormaPagamento `json:"FormaPagamento,omitempty" validate:"required"` // *Forma de pagamento do fornecedor.
+	TipoDocumento       *enums.TipoDocumento  `json:"TipoDocumento,omitempty" validate:"required"`  // *Tipos de documentos.
+	EmiteNFSE           *enums.Flag           `json:"EmiteNFSE,omitempty"`                          // Indica se fornecedor emite NFSe. Valor default é 'S'.
+	Ativo               *enums.Flag           `json:"Ativo,omitempty"`                              // Indica se está ativo. Valor default é 'S'.
+	CodPessoaFavorecido *int                  `json:"CodPessoaFavorecido,omitempty"`                // Código da pessoa favorecida em pagamentos ao fornecedor.
+	CodPessoaTitular    *int                  `json:"CodPessoaTitular,omitempty"`                   // Código da pessoa titular da empresa para fins previdenciários.
+	MEI                 *string               `json:"MEI,omitempty"`                                // MEI do fornecedor.
+	NIT                 *string               `json:"NIT,omitempty"`                                // NIT do fornecedor.
+	ProdutorRural       *enums.Flag           `json:"ProdutorRural,omitempty"`                      // Indica se o fornecedor é produtor rural.
+	CodigoCBO           *string               `json:"CodigoCBO,omitempty"`                          // Código CBO (Classificação Brasileira de Ocupações).
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -161,6 +124,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -181,49 +148,28 @@ type RequestResponseBody struct {
 	CodFornecedor *int `json:"CodFornecedor,omitempty"` // Código do fornecedor.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
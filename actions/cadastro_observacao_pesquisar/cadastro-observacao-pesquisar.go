@@ -1,11 +1,9 @@
 package cadastro_observacao_pesquisar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
 	"github.com/itispx/goimobiliar/erros"
@@ -14,98 +12,61 @@ import (
 
 var ACTION = "CADASTRO_OBSERVACAO_PESQUISAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	TipoOrigem     *string `json:"TipoOrigem,omitempty"`     // *Define a origem do cadastro.
-	CodOrigem      *string `json:"CodOrigem,omitempty"`      // *Código do cadastro de origem vinculado a observação. Quando tipoorigem='L' deve-se utilizar codorigem='CODIMOVEL|CODCONTRATO'.
-	TabObs         *string `json:"TabObs,omitempty"`         // Define a aba do cadastro de observação.
-	SoExcluidos    *string `json:"SoExcluidos,omitempty"`    // Campo para filtrar registros excluídos.
-	OrdenarPor     *string `json:"OrdenarPor,omitempty"`     // Ordem de exibição. Valor default é 'C'.
-	QtdeLinhas     *int    `json:"QtdeLinhas,omitempty"`     // Quantidade máxima de linhas de resposta, utilizado para obter resultados por segmentos (paginação). Se não for informado então a resposta conterá todas as linhas selecionadas pela ação. Valor default é '0'.
-	ProximasLinhas *string `json:"ProximasLinhas,omitempty"` // Campo opcional indicando que, ao invés de executar a ação, solicita as linhas do próximo segmento. Valor default é 'N'.
+	TipoOrigem     *string `json:"TipoOrigem,omitempty" validate:"required"` // *Define a origem do cadastro.
+	CodOrigem      *string `json:"CodOrigem,omitempty" validate:"required"`  // *Código do cadastro de origem vinculado a observação. Quando tipoorigem='L' deve-se utilizar codorigem='CODIMOVEL|CODCONTRATO'.
+	TabObs         *string `json:"TabObs,omitempty"`                         // Define a aba do cadastro de observação.
+	SoExcluidos    *string `json:"SoExcluidos,omitempty"`                    // Campo para filtrar registros excluídos.
+	OrdenarPor     *string `json:"OrdenarPor,omitempty"`                     // Ordem de exibição. Valor default é 'C'.
+	QtdeLinhas     *int    `json:"QtdeLinhas,omitempty"`                     // Quantidade máxima de linhas de resposta, utilizado para obter resultados por segmentos (paginação). Se não for informado então a resposta conterá todas as linhas selecionadas pela ação. Valor default é '0'.
+	ProximasLinhas *string `json:"ProximasLinhas,omitempty"`                 // Campo opcional indicando que, ao invés de executar a ação, solicita as linhas do próximo segmento. Valor default é 'N'.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -114,6 +75,37 @@ func Run(input *RunInput) (*RunOutput, error) {
 	return (*RunOutput)(handlerOutput.Body), nil
 }
 
+// All percorre todos os resultados da pesquisa, buscando os segmentos de
+// QtdeLinhas linhas com ProximasLinhas até o fim.
+func All(ctx context.Context, sess *session.Session, input *ActionInput) iter.Seq2[RequestResponseBodyObservacao, error] {
+	actionInput := ActionInput{}
+	if input != nil {
+		actionInput = *input
+	}
+
+	qtdeLinhas := consts.QtdeLinhas(actionInput.QtdeLinhas)
+	actionInput.QtdeLinhas = &qtdeLinhas
+	actionInput.ProximasLinhas = nil
+
+	return consts.All(ctx, qtdeLinhas, func(ctx context.Context, proximas bool) ([]RequestResponseBodyObservacao, error) {
+		segmentInput := actionInput
+		if proximas {
+			proximasLinhas := consts.ProximasLinhas
+			segmentInput.ProximasLinhas = &proximasLinhas
+		}
+
+		output, err := RunContext(ctx, &RunInput{
+			Session:     sess,
+			ActionInput: &segmentInput,
+		})
+		if err != nil || output.Observacoes == nil {
+			return nil, err
+		}
+
+		return *output.Observacoes, nil
+	})
+}
+
 type HandlerInput struct {
 	Session *session.Session
 	*ActionInput
@@ -133,6 +125,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -156,49 +152,28 @@ type RequestResponseBody struct {
 // TODO
 type RequestResponseBodyObservacao interface{}
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,105 +1,69 @@
 package cadastro_pessoa_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CADASTRO_PESSOA_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodPessoa *int `json:"CodPessoa,omitempty"` // *Código da pessoa.
+	CodPessoa *int `json:"CodPessoa,omitempty" validate:"required"` // *Código da pessoa.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +91,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -148,11 +116,11 @@ type RequestResponseBody struct {
 	Nome                *string                        `json:"Nome,omitempty"`                // Nome da pessoa.
 	EstadoCivil         *string                        `json:"EstadoCivil,omitempty"`         // Estado civil da pessoa.
 	Sexo                *string                        `json:"Sexo,omitempty"`                // Sexo/gênero da pessoa.
-	TipoPessoa          *string                        `json:"TipoPessoa,omitempty"`          // Tipo da pessoa.
-	CpfCnpj             *int                           `json:"CpfCnpj,omitempty"`             // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
+	TipoPessoa          *enums.TipoPessoa              `json:"TipoPessoa,omitempty"`          // Tipo da pessoa.
+	CpfCnpj             *doc.CPFCNPJ                   `json:"CpfCnpj,omitempty"`             // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
 	RG                  *string                        `json:"RG,omitempty"`                  // Número do documento de identificação da pessoa física. Não preencher se for pessoa jurídica.
 	OrgaoExpedidor      *string                        `json:"OrgaoExpedidor,omitempty"`      // Órgão que expediu o documento de identificação informado.
-	DataNascimento      *string                        `json:"DataNascimento,omitempty"`      // Data de nascimento da pessoa física ou de criação da pessoa jurídica.
+	DataNascimento      *imobdate.Date                 `json:"DataNascimento,omitempty"`      // Data de nascimento da pessoa física ou de criação da pessoa jurídica.
 	Nacionalidade       *string                        `json:"Nacionalidade,omitempty"`       // Nacionalidade da pessoa no padrão do e-Social.
 	CodNacionalidade    *int                           `json:"CodNacionalidade,omitempty"`    // Código de nacionalidade da pessoa no e-Social.
 	Naturalidade        *string                        `json:"Naturalidade,omitempty"`        // Naturalidade da pessoa no padrão do DIMOB.
@@ -160,10 +128,10 @@ type RequestResponseBody struct {
 	Celular             *string                        `json:"Celular,omitempty"`             // Número de celular.
 	Email               *string                        `json:"Email,omitempty"`               // E-mail da pessoa.
 	Contato             *string                        `json:"Contato,omitempty"`             // Informações de pessoa de contato.
-	Ativo               *string                        `json:"Ativo,omitempty"`               // Indica se está ativo.
+	Ativo               *enums.Flag                    `json:"Ativo,omitempty"`               // Indica se está ativo.
 	TipoEnderCobr       *string                        `json:"TipoEnderCobr,omitempty"`       // Tipo de endereço de cobrança que deve existir no array 'Enderecos'.
 	TipoEnderCorresp    *string                        `json:"TipoEnderCorresp,omitempty"`    // Tipo de endereço de correpondência que deve existir no array 'Enderecos'.
-	DataInclusao        *string                        `json:"DataInclusao,omitempty"`        // Data de inclusão no sistema.
+	DataInclusao        *imobdate.Date                 `json:"DataInclusao,omitempty"`        // Data de inclusão no sistema.
 	NomePai             *string                        `json:"NomePai,omitempty"`             // Nome do pai da pessoa física.
 	NomeMae             *string                        `json:"NomeMae,omitempty"`             // Nome da mãe da pessoa física.
 	CodConjuge          *int                           `json:"CodConjuge,omitempty"`          // Código de pessoa do cônjuge.
@@ -178,15 +146,15 @@ type RequestResponseBody struct {
 	CodProfissao        *int                           `json:"CodProfissao,omitempty"`        // Código da profissão desta pessoa.
 	Classificacao       *string                        `json:"Classificacao,omitempty"`       // Código de classificacão desta pessoa.
 	Observacao          *string                        `json:"Observacao,omitempty"`          // Texto de observação desta pessoa.
-	DataAlteracao       *string                        `json:"DataAlteracao,omitempty"`       // Data da última alteração no sistema.
+	DataAlteracao       *imobdate.Date                 `json:"DataAlteracao,omitempty"`       // Data da última alteração no sistema.
 	Enderecos           *[]RequestResponseBodyEndereco `json:"Enderecos,omitempty"`           //
-	Locatario           *string                        `json:"Locatario,omitempty"`           // Indica se é locatário.
-	Proprietario        *string                        `json:"Proprietario,omitempty"`        // Indica se é proprietário.
-	Fiador              *string                        `json:"Fiador,omitempty"`              // Indica se é fiador.
-	Sindico             *string                        `json:"Sindico,omitempty"`             // Indica se é síndico.
-	Condomino           *string                        `json:"Condomino,omitempty"`           // Indica se é condômino.
-	Beneficiario        *string                        `json:"Beneficiario,omitempty"`        // Indica se é beneficiário.
-	Procurador          *string                        `json:"Procurador,omitempty"`          // Indica se é procurador.
+	Locatario           *enums.Flag                    `json:"Locatario,omitempty"`           // Indica se é locatário.
+	Proprietario        *enums.Flag                    `json:"Proprietario,omitempty"`        // Indica se é proprietário.
+	Fiador              *enums.Flag                    `json:"Fiador,omitempty"`              // Indica se é fiador.
+	Sindico             *enums.Flag                    `json:"Sindico,omitempty"`             // Indica se é síndico.
+	Condomino           *enums.Flag                    `json:"Condomino,omitempty"`           // Indica se é condômino.
+	Beneficiario        *enums.Flag                    `json:"Beneficiario,omitempty"`        // Indica se é beneficiário.
+	Procurador          *enums.Flag                    `json:"Procurador,omitempty"`          // Indica se é procurador.
 	Assessor            *string                        `json:"Assessor,omitempty"`            // Código de usuário do assessor responsável.
 	LocatarioAdicional  *string                        `json:"LocatarioAdicional,omitempty"`  // Se é locatário adicional.
 	DebitadoLocacao     *string                        `json:"DebitadoLocacao,omitempty"`     // Se é debitado de locação.
@@ -215,49 +183,28 @@ type RequestResponseBodyEndereco struct {
 	Telefone2   *string `json:"Telefone2,omitempty"`   // Número de telefone alternativo.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
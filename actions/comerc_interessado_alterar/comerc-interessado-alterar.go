@@ -1,134 +1,98 @@
 package comerc_interessado_alterar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "COMERC_INTERESSADO_ALTERAR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	CodInteressado      *int    `json:"CodInteressado,omitempty"`      // *Código do Interessado.
-	Nome                *string `json:"Nome,omitempty"`                // Nome do Interessado.
-	TipoPessoa          *string `json:"TipoPessoa,omitempty"`          // Tipo da pessoa.
-	CpfCnpj             *int    `json:"CpfCnpj,omitempty"`             // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
-	RG                  *string `json:"RG,omitempty"`                  // Número do documento de identificação da pessoa física. Não preencher se for pessoa jurídica.
-	Ativo               *string `json:"Ativo,omitempty"`               // Indica se está ativo.
-	OrgaoExpedidor      *string `json:"OrgaoExpedidor,omitempty"`      // Órgão que expediu o documento de identificação informado.
-	DataNascimento      *string `json:"DataNascimento,omitempty"`      // Data de nascimento da pessoa física ou de criação da pessoa jurídica.
-	Celular             *string `json:"Celular,omitempty"`             // Número de celular.
-	Email               *string `json:"Email,omitempty"`               // E-mail do interessado.
-	Contato             *string `json:"Contato,omitempty"`             // Informações de pessoa de contato.
-	Observacao          *string `json:"Observacao,omitempty"`          // Mensagem de Observação.
-	TipoEnder           *string `json:"TipoEnder,omitempty"`           // Tipo de endereço.
-	CEP                 *int    `json:"CEP,omitempty"`                 // Número do CEP.
-	TipoLograd          *string `json:"TipoLograd,omitempty"`          // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
-	Logradouro          *string `json:"Logradouro,omitempty"`          // Logradouro do endereço. Deve ser informado apenas o nome sem o tipo de logradouro.
-	Numero              *int    `json:"Numero,omitempty"`              // Número do endereço.
-	Complemento         *string `json:"Complemento,omitempty"`         // Complemento do endereço.
-	Bairro              *string `json:"Bairro,omitempty"`              // Bairro do endereço.
-	Cidade              *string `json:"Cidade,omitempty"`              // Cidade do endereço.
-	UF                  *string `json:"UF,omitempty"`                  // Sigla da Unidade Federativa do endereço.
-	TipoComercializacao *string `json:"TipoComercializacao,omitempty"` // Informa se a comercialização é Locação ou Venda.
-	TipoDivulgacao      *string `json:"TipoDivulgacao,omitempty"`      // Tipo de divulgação que a pessoa chegou até a empresa.
-	CodVeiculo          *string `json:"CodVeiculo,omitempty"`          // Código veículo de comunicação.
-	Telefone1           *string `json:"Telefone1,omitempty"`           // Número de telefone principal.
-	Ramal1              *string `json:"Ramal1,omitempty"`              // Ramal do telefone principal.
-	Telefone2           *string `json:"Telefone2,omitempty"`           // Número de telefone alternativo.
-	Ramal2              *string `json:"Ramal2,omitempty"`              // Ramal do telefone alternativo.
-	ProcuraAtiva        *string `json:"ProcuraAtiva,omitempty"`        // Informa se a pessoa está com procura de imóveis ativa.
-	QualificaPessoa     *string `json:"QualificaPessoa,omitempty"`     // Qualificação da Pessoa.
+	CodInteressado      *int              `json:"CodInteressado,omitempty" validate:"required"` // *Código do Interessado.
+	Nome                *string           `json:"Nome,omitempty"`                               // Nome do Interessado.
+	TipoPessoa          *enums.TipoPessoa `json:"TipoPessoa,omitempty"`                         // Tipo da pessoa.
+	CpfCnpj             *doc.CPFCNPJ      `json:"CpfCnpj,omitempty"`                            // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
+	RG                  *string           `json:"RG,omitempty"`                                 // Número do documento de identificação da pessoa física. Não preencher se for pessoa jurídica.
+	Ativo               *enums.Flag       `json:"Ativo,omitempty"`                              // Indica se está ativo.
+	OrgaoExpedidor      *string           `json:"OrgaoExpedidor,omitempty"`                     // Órgão que expediu o documento de identificação informado.
+	DataNascimento      *imobdate.Date    `json:"DataNascimento,omitempty"`                     // Data de nascimento da pessoa física ou de criação da pessoa jurídica.
+	Celular             *string           `json:"Celular,omitempty"`                            // Número de celular.
+	Email               *string           `json:"Email,omitempty"`                              // E-mail do interessado.
+	Contato             *string           `json:"Contato,omitempty"`                            // Informações de pessoa de contato.
+	Observacao          *string           `json:"Observacao,omitempty"`                         // Mensagem de Observação.
+	TipoEnder           *string           `json:"TipoEnder,omitempty"`                          // Tipo de endereço.
+	CEP                 *int              `json:"CEP,omitempty"`                                // Número do CEP.
+	TipoLograd          *string           `json:"TipoLograd,omitempty"`                         // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
+	Logradouro          *string           `json:"Logradouro,omitempty"`                         // Logradouro do endereço. Deve ser informado apenas o nome sem o tipo de logradouro.
+	Numero              *int              `json:"Numero,omitempty"`                             // Número do endereço.
+	Complemento         *string           `json:"Complemento,omitempty"`                        // Complemento do endereço.
+	Bairro              *string           `json:"Bairro,omitempty"`                             // Bairro do endereço.
+	Cidade              *string           `json:"Cidade,omitempty"`                             // Cidade do endereço.
+	UF                  *string           `json:"UF,omitempty"`                                 // Sigla da Unidade Federativa do endereço.
+	TipoComercializacao *string           `json:"TipoComercializacao,omitempty"`                // Informa se a comercialização é Locação ou Venda.
+	TipoDivulgacao      *string           `json:"TipoDivulgacao,omitempty"`                     // Tipo de divulgação que a pessoa chegou até a empresa.
+	CodVeiculo          *string           `json:"CodVeiculo,omitempty"`                         // Código veículo de comunicação.
+	Telefone1           *string           `json:"Telefone1,omitempty"`                          // Número de telefone principal.
+	Ramal1              *string           `json:"Ramal1,omitempty"`                             // Ramal do telefone principal.
+	Telefone2           *string           `json:"Telefone2,omitempty"`                          // Número de telefone alternativo.
+	Ramal2              *string           `json:"Ramal2,omitempty"`                             // Ramal do telefone alternativo.
+	ProcuraAtiva        *enums.Flag       `json:"ProcuraAtiva,omitempty"`                       // Informa se a pessoa está com procura de imóveis ativa.
+	QualificaPessoa     *string           `json:"QualificaPessoa,omitempty"`                    // Qualificação da Pessoa.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -156,6 +120,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -176,49 +144,28 @@ type RequestResponseBody struct {
 	CodInteressado *int `json:"CodInteressado,omitempty"` // Código do Interessado.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,105 +1,69 @@
 package condom_condominio_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_CONDOMINIO_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodCondominio *int `json:"CodCondominio,omitempty"` // *Código do condomínio.
+	CodCondominio *int `json:"CodCondominio,omitempty" validate:"required"` // *Código do condomínio.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +91,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -146,14 +114,14 @@ type RequestResponseHeader struct {
 type RequestResponseBody struct {
 	CodCondominio        *int                        `json:"CodCondominio,omitempty"`        //	Código do condomínio.
 	NomeCondominio       *string                     `json:"NomeCondominio,omitempty"`       // Nome do condomínio.
-	CNPJ                 *int                        `json:"CNPJ,omitempty"`                 // CNPJ do condomínio.
+	CNPJ                 *doc.CPFCNPJ                `json:"CNPJ,omitempty"`                 // CNPJ do condomínio.
 	TotalFracao          *float64                    `json:"TotalFracao,omitempty"`          //	Total das frações das economias.
 	TotaldeBlocos        *int                        `json:"TotaldeBlocos,omitempty"`        //	Total de blocos do condomínio.
 	DiaVencimentoDoc     *int                        `json:"DiaVencimentoDoc,omitempty"`     //	Dia de vencimento do boleto de condomínio.
-	UltimaCompetenciaDoc *string                     `json:"UltimaCompetenciaDoc,omitempty"` // Competência do último boleto gerado no formato YYYYMM.
+	UltimaCompetenciaDoc *imobdate.Competencia       `json:"UltimaCompetenciaDoc,omitempty"` // Competência do último boleto gerado no formato YYYYMM.
 	CodBlocoBase         *string                     `json:"CodBlocoBase,omitempty"`         // Bloco base/principal do condomínio.
-	Ativo                *string                     `json:"Ativo,omitempty"`                // Indica se está ativo.
-	DataInicioAdm        *string                     `json:"DataInicioAdm,omitempty"`        // Data do início da administracao.
+	Ativo                *enums.Flag                 `json:"Ativo,omitempty"`                // Indica se está ativo.
+	DataInicioAdm        *imobdate.Date              `json:"DataInicioAdm,omitempty"`        // Data do início da administracao.
 	EnderecoPrincipal    *string                     `json:"EnderecoPrincipal,omitempty"`    // Endereço principal do condomínio.
 	Cidade               *string                     `json:"Cidade,omitempty"`               // Cidade do endereço.
 	UF                   *string                     `json:"UF,omitempty"`                   // Sigla da Unidade Federativa do endereço.
@@ -161,7 +129,7 @@ type RequestResponseBody struct {
 	AssessorNome         *string                     `json:"AssessorNome,omitempty"`         // Nome do assessor/gestor.
 	LojaNome             *string                     `json:"LojaNome,omitempty"`             // Nome da loja/agência.
 	BloqueioPagamento    *string                     `json:"BloqueioPagamento,omitempty"`    // Marcação de bloqueio de pagamento.
-	DataDistrato         *string                     `json:"DataDistrato,omitempty"`         // Data de encerramento.
+	DataDistrato         *imobdate.Date              `json:"DataDistrato,omitempty"`         // Data de encerramento.
 	Categoria            *string                     `json:"Categoria,omitempty"`            // Tipo do condominio.
 	Classificacao        *string                     `json:"Classificacao,omitempty"`        // Classificação do condominio (aba 'contrato' da tela de cadastro).
 	Blocos               *[]RequestResponseBodyBloco `json:"Blocos,omitempty"`               //
@@ -181,62 +149,41 @@ type RequestResponseBodyBloco struct {
 	Bairro        *string                             `json:"Bairro,omitempty"`        // Bairro do endereço.
 	QtdeEconomias *int                                `json:"QtdeEconomias,omitempty"` // Total de economias do bloco.
 	OrdemBloco    *int                                `json:"OrdemBloco,omitempty"`    // Ordem de apresentação do bloco/conta.
-	BlocoAtivo    *string                             `json:"BlocoAtivo,omitempty"`    // Informa se o Bloco/Conta está ativo.
+	BlocoAtivo    *enums.Flag                         `json:"BlocoAtivo,omitempty"`    // Informa se o Bloco/Conta está ativo.
 	Conselho      *[]RequestResponseBodyBlocoConselho `json:"Conselho,omitempty"`      //
 }
 
 type RequestResponseBodyBlocoConselho struct {
-	CodPessoa           *int    `json:"CodPessoa,omitempty"`           // Código da pessoa.
-	Cargo               *string `json:"Cargo,omitempty"`               // Cargo no conselho de condomínio.
-	InicioMandato       *string `json:"InicioMandato,omitempty"`       // Data do início do mandato.
-	FinalMandato        *string `json:"FinalMandato,omitempty"`        // Data do final de mandato.
-	SindicoProfissional *string `json:"SindicoProfissional,omitempty"` // Indicação de síndico profissional.
-	CodFornecedor       *int    `json:"CodFornecedor,omitempty"`       // Código de fornecedor (se for o caso).
+	CodPessoa           *int           `json:"CodPessoa,omitempty"`           // Código da pessoa.
+	Cargo               *string        `json:"Cargo,omitempty"`               // Cargo no conselho de condomínio.
+	InicioMandato       *imobdate.Date `json:"InicioMandato,omitempty"`       // Data do início do mandato.
+	FinalMandato        *imobdate.Date `json:"FinalMandato,omitempty"`        // Data do final de mandato.
+	SindicoProfissional *string        `json:"SindicoProfissional,omitempty"` // Indicação de síndico profissional.
+	CodFornecedor       *int           `json:"CodFornecedor,omitempty"`       // Código de fornecedor (se for o caso).
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
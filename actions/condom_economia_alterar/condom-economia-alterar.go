@@ -1,138 +1,101 @@
 package condom_economia_alterar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_ECONOMIA_ALTERAR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	IdEconomia                     *int     `json:"IdEconomia,omitempty"`                     // *Chave principal da economia/unidade.
-	CodEconomia                    *string  `json:"CodEconomia,omitempty"`                    // Código da economia/unidade no bloco.
-	CodClasseImovel                *int     `json:"CodClasseImovel,omitempty"`                // Código da classe de imóvel.
-	CodPessoaCondomino             *int     `json:"CodPessoaCondomino,omitempty"`             // Código de pessoa do condômino desta economia/unidade.
-	CodPessoaLocat                 *int     `json:"CodPessoaLocat,omitempty"`                 // Código de pessoa do locatário desta economia/unidade.
-	CodPessoaDebContaCondomino     *int     `json:"CodPessoaDebContaCondomino,omitempty"`     // Código de pessoa do condômino para débito em conta.
-	CodPessoaDebContaLocat         *int     `json:"CodPessoaDebContaLocat,omitempty"`         // Código de pessoa do locatário para débito em conta.
-	CodFornecedorAdministradoraLoc *int     `json:"CodFornecedorAdministradoraLoc,omitempty"` // Código de fornecedor da administradora da locação.
-	CodImovelNaAdministradoraLoc   *int     `json:"CodImovelNaAdministradoraLoc,omitempty"`   // Código do imóvel na locação desta administradora.
-	CodCompensacaoIntegrada        *string  `json:"CodCompensacaoIntegrada,omitempty"`        // Código do imóvel para compensação integrada com outra administradora da locação.
-	CodFornecAdvogado              *int     `json:"CodFornecAdvogado,omitempty"`              // Código de fornecedor do advogado de cobrança dos boletos.
-	TarifaBoleto                   *string  `json:"TarifaBoleto,omitempty"`                   // Indica se o boleto tem tarifa.
-	ValorTarifaBoleto              *float64 `json:"ValorTarifaBoleto,omitempty"`              // Valor fixado da tarifa.
-	QtdeDormitorios                *int     `json:"QtdeDormitorios,omitempty"`                // Quantidade de dormitórios.
-	Fracao                         *float64 `json:"Fracao,omitempty"`                         // Fracao da economia/unidade.
-	EmiteExtrato                   *string  `json:"EmiteExtrato,omitempty"`                   // Indica qual tipo de extrato.
-	ExportaLocacao                 *string  `json:"ExportaLocacao,omitempty"`                 // Indica se exporta para locação.
-	EmiteEtiqueta                  *string  `json:"EmiteEtiqueta,omitempty"`                  // Indica se emite etiqueta.
-	RetemBoleto                    *string  `json:"RetemBoleto,omitempty"`                    // Indica se deve reter boleto.
-	ExtratoNoSite                  *string  `json:"ExtratoNoSite,omitempty"`                  // Indica se deve mostrar extrato no site.
-	EnviarEmailBoleto              *string  `json:"EnviarEmailBoleto,omitempty"`              // Indica se deve enviar boleto por e-mail.
-	GerarReciboAluguel             *string  `json:"GerarReciboAluguel,omitempty"`             // Indica se deve gerar recibo de locação.
-	IsentarTaxaPorte               *string  `json:"IsentarTaxaPorte,omitempty"`               // Indica se deve isentar taxa porte.
-	AssociarAdvogado               *string  `json:"AssociarAdvogado,omitempty"`               // Indica se deve associar um advogado aos boletos.
-	InibirMsgInadimplenciaBoleto   *string  `json:"InibirMsgInadimplenciaBoleto,omitempty"`   // Indica se deve inibir mensagem de inadimplência no boleto.
-	InibirCartaInadimplencia       *string  `json:"InibirCartaInadimplencia,omitempty"`       // Indica se deve inibir impressão da carta de inadimplência.
-	InibirEmailInadimplencia       *string  `json:"InibirEmailInadimplencia,omitempty"`       // Indica se deve inibir envio por email da carta de inadimplência.
-	InibirExportacao               *string  `json:"InibirExportacao,omitempty"`               // Indica se deve gerar recibo de locação.
-	BloqueioNegativa               *string  `json:"BloqueioNegativa,omitempty"`               // Indica se deve bloquear a negativa de débitos.
-	ObservacaoEconomia             *string  `json:"ObservacaoEconomia,omitempty"`             // Observação sobre esta economia/unidade.
-	ObservacaoBoleto               *string  `json:"ObservacaoBoleto,omitempty"`               // Texto para constar nas observações do boleto.
-	LocalEnderCobr                 *string  `json:"LocalEnderCobr,omitempty"`                 // Local do endereço de cobrança.
-	LocalEnderCorresp              *string  `json:"LocalEnderCorresp,omitempty"`              // Local do endereço de correpondência.
-	Ativa                          *string  `json:"Ativa,omitempty"`                          // Indica se está ativa.
+	IdEconomia                     *int         `json:"IdEconomia,omitempty" validate:"required"` // *Chave principal da economia/unidade.
+	CodEconomia                    *string      `json:"CodEconomia,omitempty"`                    // Código da economia/unidade no bloco.
+	CodClasseImovel                *int         `json:"CodClasseImovel,omitempty"`                // Código da classe de imóvel.
+	CodPessoaCondomino             *int         `json:"CodPessoaCondomino,omitempty"`             // Código de pessoa do condômino desta economia/unidade.
+	CodPessoaLocat                 *int         `json:"CodPessoaLocat,omitempty"`                 // Código de pessoa do locatário desta economia/unidade.
+	CodPessoaDebContaCondomino     *int         `json:"CodPessoaDebContaCondomino,omitempty"`     // Código de pessoa do condômino para débito em conta.
+	CodPessoaDebContaLocat         *int         `json:"CodPessoaDebContaLocat,omitempty"`         // Código de pessoa do locatário para débito em conta.
+	CodFornecedorAdministradoraLoc *int         `json:"CodFornecedorAdministradoraLoc,omitempty"` // Código de fornecedor da administradora da locação.
+	CodImovelNaAdministradoraLoc   *int         `json:"CodImovelNaAdministradoraLoc,omitempty"`   // Código do imóvel na locação desta administradora.
+	CodCompensacaoIntegrada        *string      `json:"CodCompensacaoIntegrada,omitempty"`        // Código do imóvel para compensação integrada com outra administradora da locação.
+	CodFornecAdvogado              *int         `json:"CodFornecAdvogado,omitempty"`              // Código de fornecedor do advogado de cobrança dos boletos.
+	TarifaBoleto                   *enums.Flag  `json:"TarifaBoleto,omitempty"`                   // Indica se o boleto tem tarifa.
+	ValorTarifaBoleto              *money.Money `json:"ValorTarifaBoleto,omitempty"`              // Valor fixado da tarifa.
+	QtdeDormitorios                *int         `json:"QtdeDormitorios,omitempty"`                // Quantidade de dormitórios.
+	Fracao                         *float64     `json:"Fracao,omitempty"`                         // Fracao da economia/unidade.
+	EmiteExtrato                   *string      `json:"EmiteExtrato,omitempty"`                   // Indica qual tipo de extrato.
+	ExportaLocacao                 *enums.Flag  `json:"ExportaLocacao,omitempty"`                 // Indica se exporta para locação.
+	EmiteEtiqueta                  *enums.Flag  `json:"EmiteEtiqueta,omitempty"`                  // Indica se emite etiqueta.
+	RetemBoleto                    *enums.Flag  `json:"RetemBoleto,omitempty"`                    // Indica se deve reter boleto.
+	ExtratoNoSite                  *enums.Flag  `json:"ExtratoNoSite,omitempty"`                  // Indica se deve mostrar extrato no site.
+	EnviarEmailBoleto              *enums.Flag  `json:"EnviarEmailBoleto,omitempty"`              // Indica se deve enviar boleto por e-mail.
+	GerarReciboAluguel             *enums.Flag  `json:"GerarReciboAluguel,omitempty"`             // Indica se deve gerar recibo de locação.
+	IsentarTaxaPorte               *enums.Flag  `json:"IsentarTaxaPorte,omitempty"`               // Indica se deve isentar taxa porte.
+	AssociarAdvogado               *enums.Flag  `json:"AssociarAdvogado,omitempty"`               // Indica se deve associar um advogado aos boletos.
+	InibirMsgInadimplenciaBoleto   *enums.Flag  `json:"InibirMsgInadimplenciaBoleto,omitempty"`   // Indica se deve inibir mensagem de inadimplência no boleto.
+	InibirCartaInadimplencia       *enums.Flag  `json:"InibirCartaInadimplencia,omitempty"`       // Indica se deve inibir impressão da carta de inadimplência.
+	InibirEmailInadimplencia       *enums.Flag  `json:"InibirEmailInadimplencia,omitempty"`       // Indica se deve inibir envio por email da carta de inadimplência.
+	InibirExportacao               *enums.Flag  `json:"InibirExportacao,omitempty"`               // Indica se deve gerar recibo de locação.
+	BloqueioNegativa               *enums.Flag  `json:"BloqueioNegativa,omitempty"`               // Indica se deve bloquear a negativa de débitos.
+	ObservacaoEconomia             *string      `json:"ObservacaoEconomia,omitempty"`             // Observação sobre esta economia/unidade.
+	ObservacaoBoleto               *string      `json:"ObservacaoBoleto,omitempty"`               // Texto para constar nas observações do boleto.
+	LocalEnderCobr                 *string      `json:"LocalEnderCobr,omitempty"`                 // Local do endereço de cobrança.
+	LocalEnderCorresp              *string      `json:"LocalEnderCorresp,omitempty"`              // Local do endereço de correpondência.
+	Ativa                          *enums.Flag  `json:"Ativa,omitempty"`                          // Indica se está ativa.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -160,6 +123,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -180,49 +147,28 @@ type RequestResponseBody struct {
 	IdEconomia *int `json:"IdEconomia,omitempty"` // Chave principal da economia/unidade.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
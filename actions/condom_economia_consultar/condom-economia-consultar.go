@@ -1,105 +1,69 @@
 package condom_economia_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_ECONOMIA_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	IdEconomia *int `json:"IdEconomia,omitempty"` // *Chave principal da economia/unidade.
+	IdEconomia *int `json:"IdEconomia,omitempty" validate:"required"` // *Chave principal da economia/unidade.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +91,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -144,110 +112,89 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	IdEconomia                     *int     `json:"IdEconomia,omitempty"`                     // Chave principal da economia/unidade.
-	CodCondominio                  *int     `json:"CodCondominio,omitempty"`                  // Código do condomínio.
-	CodBloco                       *string  `json:"CodBloco,omitempty"`                       // Código do bloco do condomínio.
-	CodEconomia                    *string  `json:"CodEconomia,omitempty"`                    // Código da economia/unidade no bloco.
-	CodClasseImovel                *int     `json:"CodClasseImovel,omitempty"`                // Código da classe de imóvel.
-	DescrClasseImovel              *string  `json:"DescrClasseImovel,omitempty"`              // Descrição da classe de imóvel da economia/unidade.
-	CodPessoaCondomino             *int     `json:"CodPessoaCondomino,omitempty"`             // Código de pessoa do condômino desta economia/unidade.
-	CodPessoaDebContaCondomino     *int     `json:"CodPessoaDebContaCondomino,omitempty"`     // Código de pessoa do condômino para débito em conta.
-	CodPessoaDebContaLocat         *int     `json:"CodPessoaDebContaLocat,omitempty"`         // Código de pessoa do locatário para débito em conta.
-	Nome                           *string  `json:"Nome,omitempty"`                           // Nome do condômino.
-	Celular                        *string  `json:"Celular,omitempty"`                        // Número de celular do condomino.
-	Email                          *string  `json:"Email,omitempty"`                          // E-mail do condômino.
-	CodPessoaLocat                 *int     `json:"CodPessoaLocat,omitempty"`                 // Código de pessoa do locatário desta economia/unidade.
-	NomeLocat                      *string  `json:"NomeLocat,omitempty"`                      // Nome do locatário.
-	Contato                        *string  `json:"Contato,omitempty"`                        // Informações de contato.
-	TipoPessoa                     *string  `json:"TipoPessoa,omitempty"`                     // Tipo da pessoa.
-	CpfCnpj                        *string  `json:"CpfCnpj,omitempty"`                        // CPF/CNPJ do condômino.
-	QtdeDormitorios                *int     `json:"QtdeDormitorios,omitempty"`                // Quantidade de dormitórios.
-	Fracao                         *float64 `json:"Fracao,omitempty"`                         // Fracao da economia/unidade.
-	EmiteExtrato                   *string  `json:"EmiteExtrato,omitempty"`                   // Indica qual tipo de extrato.
-	ExportaLocacao                 *string  `json:"ExportaLocacao,omitempty"`                 // Indica se exporta para locação.
-	EmiteEtiqueta                  *string  `json:"EmiteEtiqueta,omitempty"`                  // Indica se emite etiqueta.
-	TarifaBoleto                   *string  `json:"TarifaBoleto,omitempty"`                   // Indica se o boleto tem tarifa.
-	ValorTarifaBoleto              *float64 `json:"ValorTarifaBoleto,omitempty"`              // Valor fixado da tarifa.
-	CodFornecedorAdministradoraLoc *int     `json:"CodFornecedorAdministradoraLoc,omitempty"` // Código de fornecedor da administradora da locação.
-	CodImovelNaAdministradoraLoc   *int     `json:"CodImovelNaAdministradoraLoc,omitempty"`   // Código do imóvel na locação desta administradora.
-	CodCompensacaoIntegrada        *string  `json:"CodCompensacaoIntegrada,omitempty"`        // Código do imóvel para compensação integrada com outra administradora da locação.
-	RetemBoleto                    *string  `json:"RetemBoleto,omitempty"`                    // Indica se deve reter boleto.
-	ExtratoNoSite                  *string  `json:"ExtratoNoSite,omitempty"`                  // Indica se deve mostrar extrato no site.
-	EnviarEmailBoleto              *string  `json:"EnviarEmailBoleto,omitempty"`              // Indica se deve enviar boleto por e-mail.
-	GerarReciboAluguel             *string  `json:"GerarReciboAluguel,omitempty"`             // Indica se deve gerar recibo de locação.
-	IsentarTaxaPorte               *string  `json:"IsentarTaxaPorte,omitempty"`               // Indica se deve isentar taxa porte.
-	AssociarAdvogado               *string  `json:"AssociarAdvogado,omitempty"`               // Indica se deve associar um advogado aos boletos.
-	CodFornecAdvogado              *int     `json:"CodFornecAdvogado,omitempty"`              // Código de fornecedor do advogado de cobrança dos boletos.
-	InibirMsgInadimplenciaBoleto   *string  `json:"InibirMsgInadimplenciaBoleto,omitempty"`   // Indica se deve inibir mensagem de inadimplência no boleto.
-	InibirCartaInadimplencia       *string  `json:"InibirCartaInadimplencia,omitempty"`       // Indica se deve inibir impressão da carta de inadimplência.
-	InibirEmailInadimplencia       *string  `json:"InibirEmailInadimplencia,omitempty"`       // Indica se deve inibir envio por email da carta de inadimplência.
-	InibirExportacao               *string  `json:"InibirExportacao,omitempty"`               // Indica se deve gerar recibo de locação.
-	ObservacaoEconomia             *string  `json:"ObservacaoEconomia,omitempty"`             // Observação sobre esta economia/unidade.
-	ObservacaoBoleto               *string  `json:"ObservacaoBoleto,omitempty"`               // Texto para constar nas observações do boleto.
-	LocalEnderCobr                 *string  `json:"LocalEnderCobr,omitempty"`                 // Local do endereço de cobrança.
-	TipoLogradCobr                 *string  `json:"TipoLogradCobr,omitempty"`                 // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
-	LogradouroCobr                 *string  `json:"LogradouroCobr,omitempty"`                 // Logradouro do endereço de cobrança.
-	NumeroCobr                     *int     `json:"NumeroCobr,omitempty"`                     // Número do endereço.
-	ComplementoCobr                *string  `json:"ComplementoCobr,omitempty"`                // Complemento do endereço.
-	CidadeCobr                     *string  `json:"CidadeCobr,omitempty"`                     // Cidade do endereço.
-	BairroCobr                     *string  `json:"BairroCobr,omitempty"`                     // Bairro do endereço.
-	CEPCobr                        *int     `json:"CEPCobr,omitempty"`                        // Número do CEP.
-	UFCobr                         *string  `json:"UFCobr,omitempty"`                         // Sigla da Unidade Federativa do endereço.
-	LocalEnderCorresp              *string  `json:"LocalEnderCorresp,omitempty"`              // Local do endereço de correpondência.
-	TipoLogradCorresp              *string  `json:"TipoLogradCorresp,omitempty"`              // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
-	LogradouroCorresp              *string  `json:"LogradouroCorresp,omitempty"`              // Logradouro do endereço de correpondência.
-	NumeroCorresp                  *int     `json:"NumeroCorresp,omitempty"`                  // Número do endereço.
-	ComplementoCorresp             *string  `json:"ComplementoCorresp,omitempty"`             // Complemento do endereço.
-	CidadeCorresp                  *string  `json:"CidadeCorresp,omitempty"`                  // Cidade do endereço.
-	BairroCorresp                  *string  `json:"BairroCorresp,omitempty"`                  // Bairro do endereço.
-	CEPCorresp                     *int     `json:"CEPCorresp,omitempty"`                     // Número do CEP.
-	UFCorresp                      *string  `json:"UFCorresp,omitempty"`                      // Sigla da Unidade Federativa do endereço.
-	Ativa                          *string  `json:"Ativa,omitempty"`                          // Indica se está ativa.
+	IdEconomia                     *int              `json:"IdEconomia,omitempty"`                     // Chave principal da economia/unidade.
+	CodCondominio                  *int              `json:"CodCondominio,omitempty"`                  // Código do condomínio.
+	CodBloco                       *string           `json:"CodBloco,omitempty"`                       // Código do bloco do condomínio.
+	CodEconomia                    *string           `json:"CodEconomia,omitempty"`                    // Código da economia/unidade no bloco.
+	CodClasseImovel                *int              `json:"CodClasseImovel,omitempty"`                // Código da classe de imóvel.
+	DescrClasseImovel              *string           `json:"DescrClasseImovel,omitempty"`              // Descrição da classe de imóvel da economia/unidade.
+	CodPessoaCondomino             *int              `json:"CodPessoaCondomino,omitempty"`             // Código de pessoa do condômino desta economia/unidade.
+	CodPessoaDebContaCondomino     *int              `json:"CodPessoaDebContaCondomino,omitempty"`     // Código de pessoa do condômino para débito em conta.
+	CodPessoaDebContaLocat         *int              `json:"CodPessoaDebContaLocat,omitempty"`         // Código de pessoa do locatário para débito em conta.
+	Nome                           *string           `json:"Nome,omitempty"`                           // Nome do condômino.
+	Celular                        *string           `json:"Celular,omitempty"`                        // Número de celular do condomino.
+	Email                          *string           `json:"Email,omitempty"`                          // E-mail do condômino.
+	CodPessoaLocat                 *int              `json:"CodPessoaLocat,omitempty"`                 // Código de pessoa do locatário desta economia/unidade.
+	NomeLocat                      *string           `json:"NomeLocat,omitempty"`                      // Nome do locatário.
+	Contato                        *string           `json:"Contato,omitempty"`                        // Informações de contato.
+	TipoPessoa                     *enums.TipoPessoa `json:"TipoPessoa,omitempty"`                     // Tipo da pessoa.
+	CpfCnpj                        *doc.CPFCNPJTexto `json:"CpfCnpj,omitempty"`                        // CPF/CNPJ do condômino.
+	QtdeDormitorios                *int              `json:"QtdeDormitorios,omitempty"`                // Quantidade de dormitórios.
+	Fracao                         *float64          `json:"Fracao,omitempty"`                         // Fracao da economia/unidade.
+	EmiteExtrato                   *string           `json:"EmiteExtrato,omitempty"`                   // Indica qual tipo de extrato.
+	ExportaLocacao                 *enums.Flag       `json:"ExportaLocacao,omitempty"`                 // Indica se exporta para locação.
+	EmiteEtiqueta                  *enums.Flag       `json:"EmiteEtiqueta,omitempty"`                  // Indica se emite etiqueta.
+	TarifaBoleto                   *enums.Flag       `json:"TarifaBoleto,omitempty"`                   // Indica se o boleto tem tarifa.
+	ValorTarifaBoleto              *money.Money      `json:"ValorTarifaBoleto,omitempty"`              // Valor fixado da tarifa.
+	CodFornecedorAdministradoraLoc *int              `json:"CodFornecedorAdministradoraLoc,omitempty"` // Código de fornecedor da administradora da locação.
+	CodImovelNaAdministradoraLoc   *int              `json:"CodImovelNaAdministradoraLoc,omitempty"`   // Código do imóvel na locação desta administradora.
+	CodCompensacaoIntegrada        *string           `json:"CodCompensacaoIntegrada,omitempty"`        // Código do imóvel para compensação integrada com outra administradora da locação.
+	RetemBoleto                    *enums.Flag       `json:"RetemBoleto,omitempty"`                    // Indica se deve reter boleto.
+	ExtratoNoSite                  *enums.Flag       `json:"ExtratoNoSite,omitempty"`                  // Indica se deve mostrar extrato no site.
+	EnviarEmailBoleto              *enums.Flag       `json:"EnviarEmailBoleto,omitempty"`              // Indica se deve enviar boleto por e-mail.
+	GerarReciboAluguel             *enums.Flag       `json:"GerarReciboAluguel,omitempty"`             // Indica se deve gerar recibo de locação.
+	IsentarTaxaPorte               *enums.Flag       `json:"IsentarTaxaPorte,omitempty"`               // Indica se deve isentar taxa porte.
+	AssociarAdvogado               *enums.Flag       `json:"AssociarAdvogado,omitempty"`               // Indica se deve associar um advogado aos boletos.
+	CodFornecAdvogado              *int              `json:"CodFornecAdvogado,omitempty"`              // Código de fornecedor do advogado de cobrança dos boletos.
+	InibirMsgInadimplenciaBoleto   *enums.Flag       `json:"InibirMsgInadimplenciaBoleto,omitempty"`   // Indica se deve inibir mensagem de inadimplência no boleto.
+	InibirCartaInadimplencia       *enums.Flag       `json:"InibirCartaInadimplencia,omitempty"`       // Indica se deve inibir impressão da carta de inadimplência.
+	InibirEmailInadimplencia       *enums.Flag       `json:"InibirEmailInadimplencia,omitempty"`       // Indica se deve inibir envio por email da carta de inadimplência.
+	InibirExportacao               *enums.Flag       `json:"InibirExportacao,omitempty"`               // Indica se deve gerar recibo de locação.
+	ObservacaoEconomia             *string           `json:"ObservacaoEconomia,omitempty"`             // Observação sobre esta economia/unidade.
+	ObservacaoBoleto               *string           `json:"ObservacaoBoleto,omitempty"`               // Texto para constar nas observações do boleto.
+	LocalEnderCobr                 *string           `json:"LocalEnderCobr,omitempty"`                 // Local do endereço de cobrança.
+	TipoLogradCobr                 *string           `json:"TipoLogradCobr,omitempty"`                 // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
+	LogradouroCobr                 *string           `json:"LogradouroCobr,omitempty"`                 // Logradouro do endereço de cobrança.
+	NumeroCobr                     *int              `json:"NumeroCobr,omitempty"`                     // Número do endereço.
+	ComplementoCobr                *string           `json:"ComplementoCobr,omitempty"`                // Complemento do endereço.
+	CidadeCobr                     *string           `json:"CidadeCobr,omitempty"`                     // Cidade do endereço.
+	BairroCobr                     *string           `json:"BairroCobr,omitempty"`                     // Bairro do endereço.
+	CEPCobr                        *int              `json:"CEPCobr,omitempty"`                        // Número do CEP.
+	UFCobr                         *string           `json:"UFCobr,omitempty"`                         // Sigla da Unidade Federativa do endereço.
+	LocalEnderCorresp              *string           `json:"LocalEnderCorresp,omitempty"`              // Local do endereço de correpondência.
+	TipoLogradCorresp              *string           `json:"TipoLogradCorresp,omitempty"`              // Tipo de logradouro abreviado ou por extenso ('R' ou 'RUA', 'AV' ou 'AVENIDA', etc.).
+	LogradouroCorresp              *string           `json:"LogradouroCorresp,omitempty"`              // Logradouro do endereço de correpondência.
+	NumeroCorresp                  *int              `json:"NumeroCorresp,omitempty"`                  // Número do endereço.
+	ComplementoCorresp             *string           `json:"ComplementoCorresp,omitempty"`             // Complemento do endereço.
+	CidadeCorresp                  *string           `json:"CidadeCorresp,omitempty"`                  // Cidade do endereço.
+	BairroCorresp                  *string           `json:"BairroCorresp,omitempty"`                  // Bairro do endereço.
+	CEPCorresp                     *int              `json:"CEPCorresp,omitempty"`                     // Número do CEP.
+	UFCorresp                      *string           `json:"UFCorresp,omitempty"`                      // Sigla da Unidade Federativa do endereço.
+	Ativa                          *enums.Flag       `json:"Ativa,omitempty"`                          // Indica se está ativa.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
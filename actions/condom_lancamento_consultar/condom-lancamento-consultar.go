@@ -1,105 +1,69 @@
 package condom_lancamento_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_LANCAMENTO_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	LanctoCondId *int `json:"LanctoCondId,omitempty"` // *Código do lançamento de condomínio.
+	LanctoCondId *int `json:"LanctoCondId,omitempty" validate:"required"` // *Código do lançamento de condomínio.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -127,6 +91,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -144,76 +112,55 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	Descricao           *string  `json:"Descricao,omitempty"`           // Descrição da taxa.
-	LanctoCondId        *int     `json:"LanctoCondId,omitempty"`        // Código do lançamento de condomínio.
-	Origem              *string  `json:"Origem,omitempty"`              // Origem do lançamento.
-	CodTaxa             *int     `json:"CodTaxa,omitempty"`             // Código da taxa que classifica este lançamento.
-	CodCondominio       *int     `json:"CodCondominio,omitempty"`       // Código do condomínio.
-	CodBloco            *string  `json:"CodBloco,omitempty"`            // Código do bloco do condomínio.
-	CodBlocoBase        *string  `json:"CodBlocoBase,omitempty"`        // Bloco base/principal do condomínio.
-	TipoLancamento      *string  `json:"TipoLancamento,omitempty"`      // Tipo de lançamento.
-	DataVencimentoExtra *string  `json:"DataVencimentoExtra,omitempty"` // Data de vencimento se tipo do documento for extra (TipoDocumento='E').
-	Competencia         *string  `json:"Competencia,omitempty"`         // Competência para a qual o lançamento será lançado.
-	CompetenciaReajuste *string  `json:"CompetenciaReajuste,omitempty"` // Competência do reajuste do lançamento.
-	PercentualReajuste  *float64 `json:"PercentualReajuste,omitempty"`  // Percentual de reajuste do lançamento.
-	NumeroParcela       *int     `json:"NumeroParcela,omitempty"`       // Número da parcela.
-	TotalParcelas       *int     `json:"TotalParcelas,omitempty"`       // Número total de parcelas.
-	DocAtrasado         *string  `json:"DocAtrasado,omitempty"`         // Indica se o DOC/boleto é atrasado.
-	Complemento         *string  `json:"Complemento,omitempty"`         // Complemento descritivo do lançamento.
-	ComplementoAuxiliar *string  `json:"ComplementoAuxiliar,omitempty"` // Complemento descritivo auxiliar do lançamento.
-	NossoNumero         *string  `json:"NossoNumero,omitempty"`         // Número de identificação bancário.
-	Gerado              *string  `json:"Gerado,omitempty"`              // Indica se o boleto já foi gerado.
-	DocExportado        *string  `json:"DocExportado,omitempty"`        // Indica se o boleto/DOC já foi exportado.
-	IdEconomia          *int     `json:"IdEconomia,omitempty"`          // Chave principal da economia/unidade.
-	TipoDocumento       *string  `json:"TipoDocumento,omitempty"`       // Tipo de boleto/DOC.
-	Valor               *float64 `json:"Valor,omitempty"`               // Valor do lançamento.
-	DebitoCredito       *string  `json:"DebitoCredito,omitempty"`       // Indica se o lançamento é de crédito ou de débito.
-	DebitarLocatario    *string  `json:"DebitarLocatario,omitempty"`    // Indica se é para debitar o locatário.
-}
-
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+	Descricao           *string               `json:"Descricao,omitempty"`           // Descrição da taxa.
+	LanctoCondId        *int                  `json:"LanctoCondId,omitempty"`        // Código do lançamento de condomínio.
+	Origem              *string               `json:"Origem,omitempty"`              // Origem do lançamento.
+	CodTaxa             *int                  `json:"CodTaxa,omitempty"`             // Código da taxa que classifica este lançamento.
+	CodCondominio       *int                  `json:"CodCondominio,omitempty"`       // Código do condomínio.
+	CodBloco            *string               `json:"CodBloco,omitempty"`            // Código do bloco do condomínio.
+	CodBlocoBase        *string               `json:"CodBlocoBase,omitempty"`        // Bloco base/principal do condomínio.
+	TipoLancamento      *string               `json:"TipoLancamento,omitempty"`      // Tipo de lançamento.
+	DataVencimentoExtra *imobdate.Date        `json:"DataVencimentoExtra,omitempty"` // Data de vencimento se tipo do documento for extra (TipoDocumento='E').
+	Competencia         *imobdate.Competencia `json:"Competencia,omitempty"`         // Competência para a qual o lançamento será lançado.
+	CompetenciaReajuste *imobdate.Competencia `json:"CompetenciaReajuste,omitempty"` // Competência do reajuste do lançamento.
+	PercentualReajuste  *float64              `json:"PercentualReajuste,omitempty"`  // Percentual de reajuste do lançamento.
+	NumeroParcela       *int                  `json:"NumeroParcela,omitempty"`       // Número da parcela.
+	TotalParcelas       *int                  `json:"TotalParcelas,omitempty"`       // Número total de parcelas.
+	DocAtrasado         *enums.Flag           `json:"DocAtrasado,omitempty"`         // Indica se o DOC/boleto é atrasado.
+	Complemento         *string               `json:"Complemento,omitempty"`         // Complemento descritivo do lançamento.
+	ComplementoAuxiliar *string               `json:"ComplementoAuxiliar,omitempty"` // Complemento descritivo auxiliar do lançamento.
+	NossoNumero         *string               `json:"NossoNumero,omitempty"`         // Número de identificação bancário.
+	Gerado              *enums.Flag           `json:"Gerado,omitempty"`              // Indica se o boleto já foi gerado.
+	DocExportado        *enums.Flag           `json:"DocExportado,omitempty"`        // Indica se o boleto/DOC já foi exportado.
+	IdEconomia          *int                  `json:"IdEconomia,omitempty"`          // Chave principal da economia/unidade.
+	TipoDocumento       *string               `json:"TipoDocumento,omitempty"`       // Tipo de boleto/DOC.
+	Valor               *money.Money          `json:"Valor,omitempty"`               // Valor do lançamento.
+	DebitoCredito       *enums.DebitoCredito  `json:"DebitoCredito,omitempty"`       // Indica se o lançamento é de crédito ou de débito.
+	DebitarLocatario    *enums.Flag           `json:"DebitarLocatario,omitempty"`    // Indica se é para debitar o locatário.
+}
+
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,127 +1,91 @@
 package condom_lancamento_pesquisar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_LANCAMENTO_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	CodCondominio       *int                   `json:"CodCondominio,omitempty"`       // *Código do condomínio.
-	CodBloco            *string                `json:"CodBloco,omitempty"`            // Código do bloco do condomínio.
-	CodBlocoBase        *string                `json:"CodBlocoBase,omitempty"`        // Bloco base/principal do condomínio.
-	Competencia         *string                `json:"Competencia,omitempty"`         // *Competência para a qual o lançamento será lançado.
-	Valor               *float64               `json:"Valor,omitempty"`               // *Valor do lançamento.
-	Complemento         *string                `json:"Complemento,omitempty"`         // *Complemento descritivo do lançamento.
-	CodTaxa             *int                   `json:"CodTaxa,omitempty"`             // *Código da taxa que classifica este lançamento.
-	Origem              *string                `json:"Origem,omitempty"`              // Origem do lançamento. Valor default é 'M'.
-	CompetenciaReajuste *string                `json:"CompetenciaReajuste,omitempty"` // Competência do reajuste do lançamento.
-	PercentualReajuste  *float64               `json:"PercentualReajuste,omitempty"`  // Percentual de reajuste do lançamento. Valor default é '0'.
-	DebitoCredito       *string                `json:"DebitoCredito,omitempty"`       // Indica se o lançamento é de crédito ou de débito. Valor default é 'D'.
-	TipoLancamento      *string                `json:"TipoLancamento,omitempty"`      // Tipo de lançamento. Valor default é 'I'.
-	NumeroParcela       *int                   `json:"NumeroParcela,omitempty"`       // *Número da parcela.
-	TotalParcelas       *int                   `json:"TotalParcelas,omitempty"`       // *Número total de parcelas.
-	TipoDocumento       *string                `json:"TipoDocumento,omitempty"`       // Tipo de boleto/DOC. Valor default é 'N'.
-	DataVencimentoExtra *string                `json:"DataVencimentoExtra,omitempty"` // Data de vencimento se tipo do documento for extra (TipoDocumento='E').
-	DocAtrasado         *string                `json:"DocAtrasado,omitempty"`         // Indica se o DOC/boleto é atrasado. Valor default é 'N'.
-	DebitarLocatario    *string                `json:"DebitarLocatario,omitempty"`    // Indica se é para debitar o locatário. Valor default é 'N'.
-	Economias           *[]ActionInputEconomia `json:"Economias,omitempty"`           // Lista de economias a lançar quando o tipo de lançamento for individual (TipoLancamento='I').
+	CodCondominio       *int                   `json:"CodCondominio,omitempty" validate:"required"` // *Código do condomínio.
+	CodBloco            *string                `json:"CodBloco,omitempty"`                          // Código do bloco do condomínio.
+	CodBlocoBase        *string                `json:"CodBlocoBase,omitempty"`                      // Bloco base/principal do condomínio.
+	Competencia         *imobdate.Competencia  `json:"Competencia,omitempty" validate:"required"`   // *Competência para a qual o lançamento será lançado.
+	Valor               *money.Money           `json:"Valor,omitempty" validate:"required"`         // *Valor do lançamento.
+	Complemento         *string                `json:"Complemento,omitempty" validate:"required"`   // *Complemento descritivo do lançamento.
+	CodTaxa             *int                   `json:"CodTaxa,omitempty" validate:"required"`       // *Código da taxa que classifica este lançamento.
+	Origem              *string                `json:"Origem,omitempty"`                            // Origem do lançamento. Valor default é 'M'.
+	CompetenciaReajuste *imobdate.Competencia  `json:"CompetenciaReajuste,omitempty"`               // Competência do reajuste do lançamento.
+	PercentualReajuste  *float64               `json:"PercentualReajuste,omitempty"`                // Percentual de reajuste do lançamento. Valor default é '0'.
+	DebitoCredito       *enums.DebitoCredito   `json:"DebitoCredito,omitempty"`                     // Indica se o lançamento é de crédito ou de débito. Valor default é 'D'.
+	TipoLancamento      *string                `json:"TipoLancamento,omitempty"`                    // Tipo de lançamento. Valor default é 'I'.
+	NumeroParcela       *int                   `json:"NumeroParcela,omitempty" validate:"required"` // *Número da parcela.
+	TotalParcelas       *int                   `json:"TotalParcelas,omitempty" validate:"required"` // *Número total de parcelas.
+	TipoDocumento       *string                `json:"TipoDocumento,omitempty"`                     // Tipo de boleto/DOC. Valor default é 'N'.
+	DataVencimentoExtra *imobdate.Date         `json:"DataVencimentoExtra,omitempty"`               // Data de vencimento se tipo do documento for extra (TipoDocumento='E').
+	DocAtrasado         *enums.Flag            `json:"DocAtrasado,omitempty"`                       // Indica se o DOC/boleto é atrasado. Valor default é 'N'.
+	DebitarLocatario    *enums.Flag            `json:"DebitarLocatario,omitempty"`                  // Indica se é para debitar o locatário. Valor default é 'N'.
+	Economias           *[]ActionInputEconomia `json:"Economias,omitempty"`                         // Lista de economias a lançar quando o tipo de lançamento for individual (TipoLancamento='I').
 }
 
 type ActionInputEconomia struct {
-	IdEconomia *int `json:"IdEconomia,omitempty"` // *Chave principal da economia/unidade.
+	IdEconomia *int `json:"IdEconomia,omitempty" validate:"required"` // *Chave principal da economia/unidade.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -149,6 +113,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -169,49 +137,28 @@ type RequestResponseBody struct {
 	LanctoCondId *int `json:"LanctoCondId,omitempty"` // Código do lançamento de condomínio.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,107 +1,71 @@
 package condom_lista_economias
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_LISTA_ECONOMIAS"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodCondominio        *int    `json:"CodCondominio,omitempty"`        // *Código do condomínio.
-	CodBloco             *string `json:"CodBloco,omitempty"`             // Código do bloco do condomínio.
-	DataAlteracaoInicial *string `json:"DataAlteracaoInicial,omitempty"` // Seleção por data de alteração.
+	CodCondominio        *int           `json:"CodCondominio,omitempty" validate:"required"` // *Código do condomínio.
+	CodBloco             *string        `json:"CodBloco,omitempty"`                          // Código do bloco do condomínio.
+	DataAlteracaoInicial *imobdate.Date `json:"DataAlteracaoInicial,omitempty"`              // Seleção por data de alteração.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -129,6 +93,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -168,9 +136,9 @@ type RequestResponseBodyBloco struct {
 	CEP           *int                                `json:"CEP"`                 // Número do CEP.
 	NomeSindico   *string                             `json:"NomeSindico"`         // Nome do síndico.
 	EmailSindico  *string                             `json:"EmailSindico"`        // E-mail do síndico.
-	CPFSindico    *string                             `json:"CPFSindico"`          // CPF do síndico.
-	ValorGas      *float64                            `json:"ValorGas"`            // Valor de consumo de gas.
-	ValorAgua     *float64                            `json:"ValorAgua"`           // Valor de consumo de água.
+	CPFSindico    *doc.CPFCNPJTexto                   `json:"CPFSindico"`          // CPF do síndico.
+	ValorGas      *money.Money                        `json:"ValorGas"`            // Valor de consumo de gas.
+	ValorAgua     *money.Money                        `json:"ValorAgua"`           // Valor de consumo de água.
 	Economias     *[]RequestResponseBodyBlocoEconomia `json:"Economias,omitempty"` //
 	Conselho      *[]RequestResponseBodyBlocoConselho `json:"Conselho,omitempty"`  //
 }
@@ -185,7 +153,7 @@ type RequestResponseBodyBlocoEconomia struct {
 	Email              *string                                     `json:"Email,omitempty"`              // E-mail do condômino.
 	Locatario          *string                                     `json:"Locatario,omitempty"`          // Nome do locatário.
 	Contato            *string                                     `json:"Contato,omitempty"`            // Informações de contato.
-	CpfCnpj            *string                                     `json:"CpfCnpj,omitempty"`            // CPF do condômino.
+	CpfCnpj            *doc.CPFCNPJTexto                           `json:"CpfCnpj,omitempty"`            // CPF do condômino.
 	Enderecos          *[]RequestResponseBodyBlocoEconomiaEndereco `json:"Enderecos,omitempty"`          //
 }
 
@@ -201,57 +169,36 @@ type RequestResponseBodyBlocoEconomiaEndereco struct {
 }
 
 type RequestResponseBodyBlocoConselho struct {
-	CodPessoa           *int    `json:"CodPessoa,omitempty"`           // Código da pessoa.
-	Cargo               *string `json:"Cargo,omitempty"`               // Cargo no conselho de condomínio.
-	InicioMandato       *string `json:"InicioMandato,omitempty"`       // Data do início do mandato.
-	FinalMandato        *string `json:"FinalMandato,omitempty"`        // Data do final de mandato.
-	SindicoProfissional *string `json:"SindicoProfissional,omitempty"` // Indicação de síndico profissional.
-	CodFornecedor       *int    `json:"CodFornecedor,omitempty"`       // Código de fornecedor (se for o caso).
+	CodPessoa           *int           `json:"CodPessoa,omitempty"`           // Código da pessoa.
+	Cargo               *string        `json:"Cargo,omitempty"`               // Cargo no conselho de condomínio.
+	InicioMandato       *imobdate.Date `json:"InicioMandato,omitempty"`       // Data do início do mandato.
+	FinalMandato        *imobdate.Date `json:"FinalMandato,omitempty"`        // Data do final de mandato.
+	SindicoProfissional *string        `json:"SindicoProfissional,omitempty"` // Indicação de síndico profissional.
+	CodFornecedor       *int           `json:"CodFornecedor,omitempty"`       // Código de fornecedor (se for o caso).
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
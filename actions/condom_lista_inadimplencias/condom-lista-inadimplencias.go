@@ -1,110 +1,74 @@
 package condom_lista_inadimplencias
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_LISTA_INADIMPLENCIAS"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodCondominio                  *int    `json:"CodCondominio,omitempty"`                  // *Código do condomínio.
-	CodBloco                       *string `json:"CodBloco,omitempty"`                       // Se informado o código do bloco então busca apenas a inadimplencia desse bloco senão busca toda a inadimplencia do condominio.
-	IdEconomia                     *int    `json:"IdEconomia,omitempty"`                     // Se informada a chave da economia/unidade então busca apenas a inadimplencia dela senão busca toda a inadimplencia do condominio.
-	IncluirDocsAcordo              *string `json:"IncluirDocsAcordo,omitempty"`              // Indica se deve incluir acordos. Valor default é 'N'.
-	IncluirObsInadimplencia        *string `json:"IncluirObsInadimplencia,omitempty"`        // Indica se deve incluir observações do jurídico nos boletos inadimplentes. Valor default é 'N'.
-	IncluirGarantidosInadimplencia *string `json:"IncluirGarantidosInadimplencia,omitempty"` // Indica se deve incluir boletos garantidos inadimplentes.
+	CodCondominio                  *int        `json:"CodCondominio,omitempty" validate:"required"` // *Código do condomínio.
+	CodBloco                       *string     `json:"CodBloco,omitempty"`                          // Se informado o código do bloco então busca apenas a inadimplencia desse bloco senão busca toda a inadimplencia do condominio.
+	IdEconomia                     *int        `json:"IdEconomia,omitempty"`                        // Se informada a chave da economia/unidade então busca apenas a inadimplencia dela senão busca toda a inadimplencia do condominio.
+	IncluirDocsAcordo              *enums.Flag `json:"IncluirDocsAcordo,omitempty"`                 // Indica se deve incluir acordos. Valor default é 'N'.
+	IncluirObsInadimplencia        *enums.Flag `json:"IncluirObsInadimplencia,omitempty"`           // Indica se deve incluir observações do jurídico nos boletos inadimplentes. Valor default é 'N'.
+	IncluirGarantidosInadimplencia *enums.Flag `json:"IncluirGarantidosInadimplencia,omitempty"`    // Indica se deve incluir boletos garantidos inadimplentes.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -132,6 +96,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -153,7 +121,7 @@ type RequestResponseBody struct {
 }
 
 type RequestResponseBodyInadimplente struct {
-	DataVencimento       *string                                              `json:"DataVencimento,omitempty"`    //	Date	Data de vencimento do documento.
+	DataVencimento       *imobdate.Date                                       `json:"DataVencimento,omitempty"`    //	Date	Data de vencimento do documento.
 	CodBloco             *string                                              `json:"CodBloco,omitempty"`          //	String(3)	Se informado o código do bloco então busca apenas a inadimplencia desse bloco senão busca toda a inadimplencia do condominio.
 	Economia             *string                                              `json:"Economia,omitempty"`          //	String	Identificação da economia.
 	DescrClasseImovel    *string                                              `json:"DescrClasseImovel,omitempty"` //	String(50)	Descrição da classe de imóvel da economia/unidade.
@@ -162,15 +130,15 @@ type RequestResponseBodyInadimplente struct {
 	Nome                 *string                                              `json:"Nome,omitempty"`              //	String(100)	Nome da pessoa.
 	NossoNumero          *string                                              `json:"NossoNumero,omitempty"`       //	String(13)	Número de identificação bancário.
 	TipoDOC              *string                                              `json:"TipoDOC,omitempty"`           //	String(1)	Tipo de boleto/DOC.
-	Competencia          *string                                              `json:"Competencia,omitempty"`       //	String(7)	Competência do documento sem quitação.
-	VlrDocumento         *float64                                             `json:"VlrDocumento,omitempty"`      //	Number(12,2)	Valor do documento.
-	VlrCorrigido         *float64                                             `json:"VlrCorrigido,omitempty"`      //	Number(12,2)	Valor corrigido.
-	Multa                *float64                                             `json:"Multa,omitempty"`             //	Number(12,2)	Multa sobre valor original.
-	Juros                *float64                                             `json:"Juros,omitempty"`             //	Number(12,2)	Juros sobre valor original.
-	Correcao             *float64                                             `json:"Correcao,omitempty"`          //	Number(12,2)	Correção monetária sobre valor original.
-	VlrHonorarios        *float64                                             `json:"VlrHonorarios,omitempty"`     //	Number(12,2)	Valor dos honorários jurídicos.
-	VlrCustas            *float64                                             `json:"VlrCustas,omitempty"`         //	Number(12,2)	Valor das custas jurídicas.
-	VlrTotal             *float64                                             `json:"VlrTotal,omitempty"`          //	Number(12,2)	Valor total com honorários e custas.
+	Competencia          *imobdate.Competencia                                `json:"Competencia,omitempty"`       //	String(7)	Competência do documento sem quitação.
+	VlrDocumento         *money.Money                                         `json:"VlrDocumento,omitempty"`      //	Number(12,2)	Valor do documento.
+	VlrCorrigido         *money.Money                                         `json:"VlrCorrigido,omitempty"`      //	Number(12,2)	Valor corrigido.
+	Multa                *money.Money                                         `json:"Multa,omitempty"`             //	Number(12,2)	Multa sobre valor original.
+	Juros                *money.Money                                         `json:"Juros,omitempty"`             //	Number(12,2)	Juros sobre valor original.
+	Correcao             *money.Money                                         `json:"Correcao,omitempty"`          //	Number(12,2)	Correção monetária sobre valor original.
+	VlrHonorarios        *money.Money                                         `json:"VlrHonorarios,omitempty"`     //	Number(12,2)	Valor dos honorários jurídicos.
+	VlrCustas            *money.Money                                         `json:"VlrCustas,omitempty"`         //	Number(12,2)	Valor das custas jurídicas.
+	VlrTotal             *money.Money                                         `json:"VlrTotal,omitempty"`          //	Number(12,2)	Valor total com honorários e custas.
 	ObsJurNomeAdv        *string                                              `json:"ObsJur_NomeAdv,omitempty"`    //	String	Nome do advogado responsável pelas observações jurídicas.
 	ObservacoesJuridicas *[]RequestResponseBodyInadimplenteObservacaoJuridica //
 }
@@ -179,49 +147,28 @@ type RequestResponseBodyInadimplenteObservacaoJuridica struct {
 	Observacao *string `json:"Observacao,omitempty"` // Observação do jurídico.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,107 +1,70 @@
 package condom_relatorio_extratocc_analitico
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_RELATORIO_EXTRATOCC_ANALITICO"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodCondominio  *int    `json:"CodCondominio,omitempty"`  // *Código do condomínio.
-	Competencia    *string `json:"Competencia,omitempty"`    // *Competência referência da Pasta.
-	ResponseFormat *string `json:"Responseformat,omitempty"` // Formato desejado da resposta.
+	CodCondominio  *int                  `json:"CodCondominio,omitempty" validate:"required"` // *Código do condomínio.
+	Competencia    *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"`   // *Competência referência da Pasta.
+	ResponseFormat *string               `json:"Responseformat,omitempty"`                    // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -129,6 +92,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -147,11 +114,11 @@ type RequestResponseHeader struct {
 
 type RequestResponseBody struct {
 	CodCondominio     *int                              `json:"CodCondominio,omitempty"`     // Código do condomínio.
-	Competencia       *string                           `json:"Competencia,omitempty"`       // Competência do extrato a gerar.
+	Competencia       *imobdate.Competencia             `json:"Competencia,omitempty"`       // Competência do extrato a gerar.
 	Contas            *[]RequestResponseBodyConta       `json:"Contas,omitempty"`            // Informações de cada bloco/conta.
 	ResumoSaldos      *[]RequestResponseBodyResumoSaldo `json:"ResumoSaldos,omitempty"`      //
-	SaldoGeral        *float64                          `json:"SaldoGeral,omitempty"`        // Saldo geral do condomínio.
-	DataProcessamento *string                           `json:"DataProcessamento,omitempty"` // Data e hora do processamento das informações.
+	SaldoGeral        *money.Money                      `json:"SaldoGeral,omitempty"`        // Saldo geral do condomínio.
+	DataProcessamento *imobdate.DateTime                `json:"DataProcessamento,omitempty"` // Data e hora do processamento das informações.
 }
 
 type RequestResponseBodyConta struct {
@@ -165,100 +132,79 @@ type RequestResponseBodyConta struct {
 }
 
 type RequestResponseBodyContaLancamentoCC struct {
-	Data         *string  `json:"Data,omitempty"`         // Data do lançamento.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
-	Saldo        *float64 `json:"Saldo,omitempty"`        // Saldo resultante do lançamento.
-	NumeroLancto *int     `json:"NumeroLancto,omitempty"` // Número do lançamento.
-	CodTaxa      *int     `json:"CodTaxa,omitempty"`      // Código da taxa deste lançamento.
+	Data         *imobdate.Date `json:"Data,omitempty"`         // Data do lançamento.
+	Historico    *string        `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money   `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money   `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	Saldo        *money.Money   `json:"Saldo,omitempty"`        // Saldo resultante do lançamento.
+	NumeroLancto *int           `json:"NumeroLancto,omitempty"` // Número do lançamento.
+	CodTaxa      *int           `json:"CodTaxa,omitempty"`      // Código da taxa deste lançamento.
 }
 
 type RequestResponseBodyContaLancamentoFuturo struct {
-	Data         *string  `json:"Data,omitempty"`         // Data do lançamento.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
-	Saldo        *float64 `json:"Saldo,omitempty"`        // Saldo resultante do lançamento.
-	NumeroLancto *int     `json:"NumeroLancto,omitempty"` // Número do lançamento.
-	CodTaxa      *int     `json:"CodTaxa,omitempty"`      // Código da taxa deste lançamento.
+	Data         *imobdate.Date `json:"Data,omitempty"`         // Data do lançamento.
+	Historico    *string        `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money   `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money   `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	Saldo        *money.Money   `json:"Saldo,omitempty"`        // Saldo resultante do lançamento.
+	NumeroLancto *int           `json:"NumeroLancto,omitempty"` // Número do lançamento.
+	CodTaxa      *int           `json:"CodTaxa,omitempty"`      // Código da taxa deste lançamento.
 }
 
 type RequestResponseBodyContaResumo struct {
 	Titulo            *string                                           `json:"Titulo,omitempty"`            // Título do resumo de lançamentos.
 	LancamentosResumo *[]RequestResponseBodyContaResumoLancamentoResumo `json:"LancamentosResumo,omitempty"` // Lançamentos de resumo.
-	SubTotal          *float64                                          `json:"SubTotal,omitempty"`          //Subtotal dos lançamentos.
+	SubTotal          *money.Money                                      `json:"SubTotal,omitempty"`          //Subtotal dos lançamentos.
 }
 
 type RequestResponseBodyContaResumoLancamentoResumo struct {
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	Historico    *string      `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
 }
 
 type RequestResponseBodyContaResumoConta struct {
-	SaldoAnterior *float64 `json:"SaldoAnterior,omitempty"` // Saldo de bloco/conta anterior aos lançamentos.
-	Despesa       *float64 `json:"Despesa,omitempty"`       // Valor total das despesas.
-	Receita       *float64 `json:"Receita,omitempty"`       // Valor total das receitas.
-	SaldoFinal    *float64 `json:"SaldoFinal,omitempty"`    // Saldo final de bloco/conta após os lançamentos.
+	SaldoAnterior *money.Money `json:"SaldoAnterior,omitempty"` // Saldo de bloco/conta anterior aos lançamentos.
+	Despesa       *money.Money `json:"Despesa,omitempty"`       // Valor total das despesas.
+	Receita       *money.Money `json:"Receita,omitempty"`       // Valor total das receitas.
+	SaldoFinal    *money.Money `json:"SaldoFinal,omitempty"`    // Saldo final de bloco/conta após os lançamentos.
 }
 
 type RequestResponseBodyContaControleBoletos struct {
-	QtdeBoletos  *int     `json:"QtdeBoletos,omitempty"`  // Quantidade de boletos.
-	ValorBoletos *float64 `json:"ValorBoletos,omitempty"` // Valor dos boletos.
-	Percentual   *float64 `json:"Percentual,omitempty"`   // Percentual dos boletos em relação ao total.
-	Controle     *string  `json:"Controle,omitempty"`     // Identificação do controle.
+	QtdeBoletos  *int         `json:"QtdeBoletos,omitempty"`  // Quantidade de boletos.
+	ValorBoletos *money.Money `json:"ValorBoletos,omitempty"` // Valor dos boletos.
+	Percentual   *float64     `json:"Percentual,omitempty"`   // Percentual dos boletos em relação ao total.
+	Controle     *string      `json:"Controle,omitempty"`     // Identificação do controle.
 }
 
 type RequestResponseBodyResumoSaldo struct {
-	CodBloco   *string  `json:"CodBloco,omitempty"`   // Código de bloco/conta.
-	NomeBloco  *string  `json:"NomeBloco,omitempty"`  // Nome de bloco/conta.
-	SaldoBloco *float64 `json:"SaldoBloco,omitempty"` // Saldo resultante dos lançamentos.
+	CodBloco   *string      `json:"CodBloco,omitempty"`   // Código de bloco/conta.
+	NomeBloco  *string      `json:"NomeBloco,omitempty"`  // Nome de bloco/conta.
+	SaldoBloco *money.Money `json:"SaldoBloco,omitempty"` // Saldo resultante dos lançamentos.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
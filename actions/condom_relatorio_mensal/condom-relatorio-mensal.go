@@ -1,109 +1,73 @@
 package condom_relatorio_mensal
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CONDOM_RELATORIO_MENSAL"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	Competencia    *string `json:"Competencia,omitempty"`    // *Competência do relatório mensal a gerar.
-	CodFilial      *int    `json:"CodFilial,omitempty"`      // Código da filial a gerar. Valor default é '000'.
-	InfosExtras    *string `json:"InfosExtras,omitempty"`    // Indica para gerar informações extras. Valor default é 'N'.
-	BoletosBancos  *string `json:"BoletosBancos,omitempty"`  // Indica para gerar informações sintéticas dos boletos por banco. Valor default é 'N'.
-	ResponseFormat *string `json:"ResponseFormat,omitempty"` // Formato desejado da resposta.
+	Competencia    *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"` // *Competência do relatório mensal a gerar.
+	CodFilial      *int                  `json:"CodFilial,omitempty"`                       // Código da filial a gerar. Valor default é '000'.
+	InfosExtras    *enums.Flag           `json:"InfosExtras,omitempty"`                     // Indica para gerar informações extras. Valor default é 'N'.
+	BoletosBancos  *enums.Flag           `json:"BoletosBancos,omitempty"`                   // Indica para gerar informações sintéticas dos boletos por banco. Valor default é 'N'.
+	ResponseFormat *string               `json:"ResponseFormat,omitempty"`                  // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -131,6 +95,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -148,40 +116,40 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	Competencia  *string                           `json:"Competencia,omitempty"`  // Competência do relatório mensal a gerar.
+	Competencia  *imobdate.Competencia             `json:"Competencia,omitempty"`  // Competência do relatório mensal a gerar.
 	InfosGerais  *RequestResponseBodyInfosGerais   `json:"InfosGerais,omitempty"`  //
 	InfosExtras  *RequestResponseBodyInfosExtras   `json:"InfosExtras,omitempty"`  // Indica para gerar informações extras.
 	TiposBoletos *[]RequestResponseBodyTipoBoletos `json:"TiposBoletos,omitempty"` //
 }
 
 type RequestResponseBodyInfosGerais struct {
-	QtdCondomAtivos        *int     `json:"QtdCondomAtivos,omitempty"`        // Quantidade de condomínios Ativos.
-	QtdCondomInativos      *int     `json:"QtdCondomInativos,omitempty"`      // Quantidade de condomínios inativos:
-	QtdEconomAtivas        *int     `json:"QtdEconomAtivas,omitempty"`        // Quantidade de economias ativas.
-	QtdEconomInativas      *int     `json:"QtdEconomInativas,omitempty"`      // Quantidade de economias inativas.
-	EconomCaptadas         *int     `json:"EconomCaptadas,omitempty"`         // Quantidade de economias captadas.
-	EconomRetiradas        *int     `json:"EconomRetiradas,omitempty"`        // Quantidade de economias retiradas.
-	QtdBoletosEmitidos     *int     `json:"QtdBoletosEmitidos,omitempty"`     // Quantidade de boletos emitidos.
-	VlrBoletosEmitidos     *float64 `json:"VlrBoletosEmitidos,omitempty"`     // Valor total dos boletos.
-	VlrTarifas             *float64 `json:"VlrTarifas,omitempty"`             // Valor total da tarifa boleto.
-	VlrTaxaCondom          *float64 `json:"VlrTaxaCondom,omitempty"`          // Valor total da taxa de condomínio.
-	VlrSegConteudoEmitido  *float64 `json:"VlrSegConteudoEmitido,omitempty"`  // Valor total de seguro conteúdo emitido.
-	VlrSegConteudoPago     *float64 `json:"VlrSegConteudoPago,omitempty"`     // Valor total de seguro conteúdo pago.
-	VlrSegConteudoRecebido *float64 `json:"VlrSegConteudoRecebido,omitempty"` // Valor recebido de seguro conteúdo no mês.
-	QtdBoletosNaoPagos     *int     `json:"QtdBoletosNaoPagos,omitempty"`     // Quantidade de boletos não pagos.
-	VlrBoletosNaoPagos     *float64 `json:"VlrBoletosNaoPagos,omitempty"`     // Valor total de boletos não pagos.
+	QtdCondomAtivos        *int         `json:"QtdCondomAtivos,omitempty"`        // Quantidade de condomínios Ativos.
+	QtdCondomInativos      *int         `json:"QtdCondomInativos,omitempty"`      // Quantidade de condomínios inativos:
+	QtdEconomAtivas        *int         `json:"QtdEconomAtivas,omitempty"`        // Quantidade de economias ativas.
+	QtdEconomInativas      *int         `json:"QtdEconomInativas,omitempty"`      // Quantidade de economias inativas.
+	EconomCaptadas         *int         `json:"EconomCaptadas,omitempty"`         // Quantidade de economias captadas.
+	EconomRetiradas        *int         `json:"EconomRetiradas,omitempty"`        // Quantidade de economias retiradas.
+	QtdBoletosEmitidos     *int         `json:"QtdBoletosEmitidos,omitempty"`     // Quantidade de boletos emitidos.
+	VlrBoletosEmitidos     *money.Money `json:"VlrBoletosEmitidos,omitempty"`     // Valor total dos boletos.
+	VlrTarifas             *money.Money `json:"VlrTarifas,omitempty"`             // Valor total da tarifa boleto.
+	VlrTaxaCondom          *money.Money `json:"VlrTaxaCondom,omitempty"`          // Valor total da taxa de condomínio.
+	VlrSegConteudoEmitido  *money.Money `json:"VlrSegConteudoEmitido,omitempty"`  // Valor total de seguro conteúdo emitido.
+	VlrSegConteudoPago     *money.Money `json:"VlrSegConteudoPago,omitempty"`     // Valor total de seguro conteúdo pago.
+	VlrSegConteudoRecebido *money.Money `json:"VlrSegConteudoRecebido,omitempty"` // Valor recebido de seguro conteúdo no mês.
+	QtdBoletosNaoPagos     *int         `json:"QtdBoletosNaoPagos,omitempty"`     // Quantidade de boletos não pagos.
+	VlrBoletosNaoPagos     *money.Money `json:"VlrBoletosNaoPagos,omitempty"`     // Valor total de boletos não pagos.
 }
 
 type RequestResponseBodyInfosExtras struct {
-	VlrTaxaAReceberTotal          *float64 `json:"VlrTaxaAReceberTotal,omitempty"`          // Valor total a receber de taxa de todas as economias ativas.
-	QtdEconomAdimplentes          *int     `json:"QtdEconomAdimplentes,omitempty"`          // Quantidade total de economias adimplentes.
-	VlrTaxaAReceberAdimplentes    *float64 `json:"VlrTaxaAReceberAdimplentes,omitempty"`    // Valor total a receber em taxas de todas as economias adimplentes.
-	QtdEconomInadimplentes        *int     `json:"QtdEconomInadimplentes,omitempty"`        // Quantidade de economias inadimplentes no momento.
-	VlrEconomInadimplentes        *float64 `json:"VlrEconomInadimplentes,omitempty"`        // Valor total a receber de economias inadimplentes.
-	QtdEconomInadimpExtraJudicial *int     `json:"QtdEconomInadimpExtraJudicial,omitempty"` // Quantidade total de economias inadimplentes ? Ação Extra Judicial.
-	VlrEconomInadimpExtraJudicial *float64 `json:"VlrEconomInadimpExtraJudicial,omitempty"` // Valor total a receber das economias inadimplentes ? Ação Extra Judicial.
-	QtdEconomInadimpJudicial      *int     `json:"QtdEconomInadimpJudicial,omitempty"`      // Quantidade de economias inadimplentes - Ação Judicial.
-	VlrEconomInadimpJudicial      *float64 `json:"VlrEconomInadimpJudicial,omitempty"`      // Valor total a receber das economias inadimplentes ? Ação Judicial.
+	VlrTaxaAReceberTotal          *money.Money `json:"VlrTaxaAReceberTotal,omitempty"`          // Valor total a receber de taxa de todas as economias ativas.
+	QtdEconomAdimplentes          *int         `json:"QtdEconomAdimplentes,omitempty"`          // Quantidade total de economias adimplentes.
+	VlrTaxaAReceberAdimplentes    *money.Money `json:"VlrTaxaAReceberAdimplentes,omitempty"`    // Valor total a receber em taxas de todas as economias adimplentes.
+	QtdEconomInadimplentes        *int         `json:"QtdEconomInadimplentes,omitempty"`        // Quantidade de economias inadimplentes no momento.
+	VlrEconomInadimplentes        *money.Money `json:"VlrEconomInadimplentes,omitempty"`        // Valor total a receber de economias inadimplentes.
+	QtdEconomInadimpExtraJudicial *int         `json:"QtdEconomInadimpExtraJudicial,omitempty"` // Quantidade total de economias inadimplentes ? Ação Extra Judicial.
+	VlrEconomInadimpExtraJudicial *money.Money `json:"VlrEconomInadimpExtraJudicial,omitempty"` // Valor total a receber das economias inadimplentes ? Ação Extra Judicial.
+	QtdEconomInadimpJudicial      *int         `json:"QtdEconomInadimpJudicial,omitempty"`      // Quantidade de economias inadimplentes - Ação Judicial.
+	VlrEconomInadimpJudicial      *money.Money `json:"VlrEconomInadimpJudicial,omitempty"`      // Valor total a receber das economias inadimplentes ? Ação Judicial.
 }
 
 type RequestResponseBodyTipoBoletos struct {
@@ -199,22 +167,22 @@ type RequestResponseBodyTipoBoletosBancos struct {
 }
 
 type RequestResponseBodyTipoBoletosBancosBoletos struct {
-	Data      *string  `json:"Data,omitempty"`      // Data.
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
+	Data      *imobdate.Date `json:"Data,omitempty"`      // Data.
+	QtdTotal  *int           `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
+	VlrTotal  *money.Money   `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
+	QtdNormal *int           `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
+	VlrNormal *money.Money   `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
+	QtdRetido *int           `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
+	VlrRetido *money.Money   `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
 }
 
 type RequestResponseBodyTipoBoletosBancosTotais struct {
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
+	QtdTotal  *int         `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
+	VlrTotal  *money.Money `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
+	QtdNormal *int         `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
+	VlrNormal *money.Money `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
+	QtdRetido *int         `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
+	VlrRetido *money.Money `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
 }
 
 type RequestResponseBodyTipoBoletosResumoGeral struct {
@@ -223,67 +191,46 @@ type RequestResponseBodyTipoBoletosResumoGeral struct {
 }
 
 type RequestResponseBodyTipoBoletosResumoGeralBoletos struct {
-	Data      *string  `json:"Data,omitempty"`      // Data.
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
+	Data      *imobdate.Date `json:"Data,omitempty"`      // Data.
+	QtdTotal  *int           `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
+	VlrTotal  *money.Money   `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
+	QtdNormal *int           `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
+	VlrNormal *money.Money   `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
+	QtdRetido *int           `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
+	VlrRetido *money.Money   `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
 }
 
 type RequestResponseBodyTipoBoletosResumoGeralTotais struct {
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
+	QtdTotal  *int         `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos no dia.
+	VlrTotal  *money.Money `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos no dia.
+	QtdNormal *int         `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras no dia.
+	VlrNormal *money.Money `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras no dia.
+	QtdRetido *int         `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos no dia.
+	VlrRetido *money.Money `json:"VlrRetido,omitempty"` // Valor dos boletos retidos no dia.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,146 +1,111 @@
 package ctapag_lancamento_alterar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAPAG_LANCAMENTO_ALTERAR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	NumeroLancto               *int     `json:"NumeroLancto,omitempty"`               // *Número do lançamento.
-	CodPessoaFavorecido        *int     `json:"CodPessoaFavorecido,omitempty"`        // Código do favorecido no cadastro de pessoas.
-	FormaPagamento             *string  `json:"FormaPagamento,omitempty"`             // Forma de pagamento do lançamento.
-	DataVencimento             *string  `json:"DataVencimento,omitempty"`             // Data de vencimento do lançamento.
-	DataEmissao                *string  `json:"DataEmissao,omitempty"`                // Data de emissão do lançamento (se TipoDocumento for 'N').
-	DataPagamento              *string  `json:"DataPagamento,omitempty"`              // Data de pagamento do lançamento (quando quitado).
-	Competencia                *string  `json:"Competencia,omitempty"`                // Competência do lançamento no formato 'YYYYMM'.
-	CodTaxa                    *int     `json:"CodTaxa,omitempty"`                    // Código da taxa que classifica este lançamento.
-	DcCcCondominio             *string  `json:"DcCcCondominio,omitempty"`             // Indicador do movimento em conta corrente de condomínios.
-	Complemento                *string  `json:"Complemento,omitempty"`                // Complemento descritivo do lançamento.
-	ComplementoAdicional1      *string  `json:"ComplementoAdicional1,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional2      *string  `json:"ComplementoAdicional2,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional3      *string  `json:"ComplementoAdicional3,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional4      *string  `json:"ComplementoAdicional4,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional5      *string  `json:"ComplementoAdicional5,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional6      *string  `json:"ComplementoAdicional6,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional7      *string  `json:"ComplementoAdicional7,omitempty"`      // Informação de complemento extra.
-	ComplementoAdicional8      *string  `json:"ComplementoAdicional8,omitempty"`      // Informação de complemento extra.
-	NumeroDocumento            *string  `json:"NumeroDocumento,omitempty"`            // Número do documento do fornecedor.
-	PrevisaoReal               *string  `json:"PrevisaoReal,omitempty"`               // Indicação de lançamento previsto ou real.
-	ContaCorrente              *string  `json:"ContaCorrente,omitempty"`              // Número da conta corrente da qual originará o pagamento bancário quando aplicado.
-	CodigoBarras               *string  `json:"CodigoBarras,omitempty"`               // Código de barras do documento (* obrigatório se origem for 'B')
-	PixQrCode                  *string  `json:"PixQrCode,omitempty"`                  // QR Code.
-	ValorBruto                 *float64 `json:"ValorBruto,omitempty"`                 // Valor bruto do documento/parcela.
-	ValorServicos              *float64 `json:"ValorServicos,omitempty"`              // Valor dos serviços. Se não informado, a base de cálculo será ValorBruto.
-	ValorBaseCalculoIss        *float64 `json:"ValorBaseCalculoIss,omitempty"`        // Base de cálculo do ISS. Se não informado, a base de cálculo será ValorServicos.
-	ValorRetencaoInss          *float64 `json:"ValorRetencaoInss,omitempty"`          // Valor do INSS a ser retido.
-	ValorRetencaoIss           *float64 `json:"ValorRetencaoIss,omitempty"`           // Valor do ISS a ser retido.
-	ValorRetencaoIrf           *float64 `json:"ValorRetencaoIrf,omitempty"`           // Valor do IRF a ser retido.
-	ValorRetencaoFederal       *float64 `json:"ValorRetencaoFederal,omitempty"`       // Valor da retenção federal a ser retida.
-	ValorJuros                 *float64 `json:"ValorJuros,omitempty"`                 // Valor do juros.
-	ValorDescontoIncondicional *float64 `json:"ValorDescontoIncondicional,omitempty"` // Valor do desconto incondicional. Este desconto é abatido da base de cálculo de impostos.
-	ValorDescontoCondicional   *float64 `json:"ValorDescontoCondicional,omitempty"`   // Valor do desconto condicional. Este desconto não é abatido da base de cálculo de impostos.
-	Comissao                   *float64 `json:"Comissao,omitempty"`                   // Valor de comissão.
-	NomePagador                *string  `json:"NomePagador,omitempty"`                // Nome do beneficiário. (Para liquidação de títulos se este for diferente do condomínio).
-	TipoPessoaPagador          *string  `json:"TipoPessoaPagador,omitempty"`          // Tipo de pessoa do pagador. (Para liquidação de títulos se este for diferente do condomínio).
-	CpfCnpjPagador             *int     `json:"CpfCnpjPagador,omitempty"`             // CPF ou CNPJ do pagador. (Para liquidação de títulos se este for diferente do condomínio).
-	NomeBeneficiario           *string  `json:"NomeBeneficiario,omitempty"`           // Nome do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	TipoPessoaBeneficiario     *string  `json:"TipoPessoaBeneficiario,omitempty"`     // Tipo de pessoa do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	CpfCnpjBeneficiario        *int     `json:"CpfCnpjBeneficiario,omitempty"`        // CPF ou CNPJ do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	RetirarQuitacao            *string  `json:"RetirarQuitacao,omitempty"`            // Retirar a data de quitação do lançamento. Valor default é 'N'.
-	RetirarRemessa             *string  `json:"RetirarRemessa,omitempty"`             // Retirar o vínculo do lançamento com uma remessa. Valor default é 'N'.
+	NumeroLancto               *int                  `json:"NumeroLancto,omitempty" validate:"required"` // *Número do lançamento.
+	CodPessoaFavorecido        *int                  `json:"CodPessoaFavorecido,omitempty"`              // Código do favorecido no cadastro de pessoas.
+	FormaPagamento             *enums.FormaPagamento `json:"FormaPagamento,omitempty"`                   // Forma de pagamento do lançamento.
+	DataVencimento             *imobdate.Date        `json:"DataVencimento,omitempty"`                   // Data de vencimento do lançamento.
+	DataEmissao                *imobdate.Date        `json:"DataEmissao,omitempty"`                      // Data de emissão do lançamento (se TipoDocumento for 'N').
+	DataPagamento              *imobdate.Date        `json:"DataPagamento,omitempty"`                    // Data de pagamento do lançamento (quando quitado).
+	Competencia                *imobdate.Competencia `json:"Competencia,omitempty"`                      // Competência do lançamento no formato 'YYYYMM'.
+	CodTaxa                    *int                  `json:"CodTaxa,omitempty"`                          // Código da taxa que classifica este lançamento.
+	DcCcCondominio             *string               `json:"DcCcCondominio,omitempty"`                   // Indicador do movimento em conta corrente de condomínios.
+	Complemento                *string               `json:"Complemento,omitempty"`                      // Complemento descritivo do lançamento.
+	ComplementoAdicional1      *string               `json:"ComplementoAdicional1,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional2      *string               `json:"ComplementoAdicional2,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional3      *string               `json:"ComplementoAdicional3,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional4      *string               `json:"ComplementoAdicional4,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional5      *string               `json:"ComplementoAdicional5,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional6      *string               `json:"ComplementoAdicional6,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional7      *string               `json:"ComplementoAdicional7,omitempty"`            // Informação de complemento extra.
+	ComplementoAdicional8      *string               `json:"ComplementoAdicional8,omitempty"`            // Informação de complemento extra.
+	NumeroDocumento            *string               `json:"NumeroDocumento,omitempty"`                  // Número do documento do fornecedor.
+	PrevisaoReal               *enums.PrevisaoReal   `json:"PrevisaoReal,omitempty"`                     // Indicação de lançamento previsto ou real.
+	ContaCorrente              *string               `json:"ContaCorrente,omitempty"`                    // Número da conta corrente da qual originará o pagamento bancário quando aplicado.
+	CodigoBarras               *string               `json:"CodigoBarras,omitempty"`                     // Código de barras do documento (* obrigatório se origem for 'B')
+	PixQrCode                  *string               `json:"PixQrCode,omitempty"`                        // QR Code.
+	ValorBruto                 *money.Money          `json:"ValorBruto,omitempty"`                       // Valor bruto do documento/parcela.
+	ValorServicos              *money.Money          `json:"ValorServicos,omitempty"`                    // Valor dos serviços. Se não informado, a base de cálculo será ValorBruto.
+	ValorBaseCalculoIss        *money.Money          `json:"ValorBaseCalculoIss,omitempty"`              // Base de cálculo do ISS. Se não informado, a base de cálculo será ValorServicos.
+	ValorRetencaoInss          *money.Money          `json:"ValorRetencaoInss,omitempty"`                // Valor do INSS a ser retido.
+	ValorRetencaoIss           *money.Money          `json:"ValorRetencaoIss,omitempty"`                 // Valor do ISS a ser retido.
+	ValorRetencaoIrf           *money.Money          `json:"ValorRetencaoIrf,omitempty"`                 // Valor do IRF a ser retido.
+	ValorRetencaoFederal       *money.Money          `json:"ValorRetencaoFederal,omitempty"`             // Valor da retenção federal a ser retida.
+	ValorJuros                 *money.Money          `json:"ValorJuros,omitempty"`                       // Valor do juros.
+	ValorDescontoIncondicional *money.Money          `json:"ValorDescontoIncondicional,omitempty"`       // Valor do desconto incondicional. Este desconto é abatido da base de cálculo de impostos.
+	ValorDescontoCondicional   *money.Money          `json:"ValorDescontoCondicional,omitempty"`         // Valor do desconto condicional. Este desconto não é abatido da base de cálculo de impostos.
+	Comissao                   *money.Money          `json:"Comissao,omitempty"`                         // Valor de comissão.
+	NomePagador                *string               `json:"NomePagador,omitempty"`                      // Nome do beneficiário. (Para liquidação de títulos se este for diferente do condomínio).
+	TipoPessoaPagador          *enums.TipoPessoa     `json:"TipoPessoaPagador,omitempty"`                // Tipo de pessoa do pagador. (Para liquidação de títulos se este for diferente do condomínio).
+	CpfCnpjPagador             *doc.CPFCNPJ          `json:"CpfCnpjPagador,omitempty"`                   // CPF ou CNPJ do pagador. (Para liquidação de títulos se este for diferente do condomínio).
+	NomeBeneficiario           *string               `json:"NomeBeneficiario,omitempty"`                 // Nome do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	TipoPessoaBeneficiario     *enums.TipoPessoa     `json:"TipoPessoaBeneficiario,omitempty"`           // Tipo de pessoa do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	CpfCnpjBeneficiario        *doc.CPFCNPJ          `json:"CpfCnpjBeneficiario,omitempty"`              // CPF ou CNPJ do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	RetirarQuitacao            *enums.Flag           `json:"RetirarQuitacao,omitempty"`                  // Retirar a data de quitação do lançamento. Valor default é 'N'.
+	RetirarRemessa             *enums.Flag           `json:"RetirarRemessa,omitempty"`                   // Retirar o vínculo do lançamento com uma remessa. Valor default é 'N'.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -168,6 +133,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -188,49 +157,28 @@ type RequestResponseBody struct {
 	NumeroLancto *int `json:"NumeroLancto,omitempty"` // Número do lançamento.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
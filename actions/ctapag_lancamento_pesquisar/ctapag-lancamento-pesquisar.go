@@ -1,128 +1,92 @@
 package ctapag_lancamento_pesquisar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAPAG_LANCAMENTO_PESQUISAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	TipoPesquisa          *string  `json:"TipoPesquisa,omitempty"`          // *Indica o tipo de pesquisa/origem.
-	CodCondominio         *int     `json:"CodCondominio,omitempty"`         // Código do condomínio do lançamento (se origem for 'C').
-	CodImovel             *int     `json:"CodImovel,omitempty"`             // Código do imóvel do lançamento (se origem for 'I').
-	CodPessoaProprietario *int     `json:"CodPessoaProprietario,omitempty"` // Código do proprietário.
-	CodPlanoContaAdm      *int     `json:"CodPlanoContaAdm,omitempty"`      // Código da conta no plano de contas da administradora (se origem for 'A').
-	CodBloco              *string  `json:"CodBloco,omitempty"`              // Código do bloco do lançamento (se origem for 'C').
-	CodFornecedor         *int     `json:"CodFornecedor,omitempty"`         // Código do fornecedor do lançamento.
-	CodPessoaFavorecido   *int     `json:"CodPessoaFavorecido,omitempty"`   // Código do favorecido no cadastro de pessoas.
-	NomeFavorecido        *string  `json:"NomeFavorecido,omitempty"`        // Nome do favorecido.
-	GrupoSoma             *int     `json:"GrupoSoma,omitempty"`             // Código do grupo de soma.
-	CodTaxa               *int     `json:"CodTaxa,omitempty"`               // Código da taxa que classifica este lançamento.
-	TipoPeriodo           *string  `json:"TipoPeriodo,omitempty"`           // Indica o tipo de período a ser pesquisado. Valor default é 'V'.
-	DataInicial           *string  `json:"DataInicial,omitempty"`           // Primeiro dia do período a ser pesquisado.
-	DataFinal             *string  `json:"DataFinal,omitempty"`             // Último dia do período a ser pesquisado.
-	Competencia           *string  `json:"Competencia,omitempty"`           // Competência do lançamento no formato 'YYYYMM'.
-	Status                *string  `json:"Status,omitempty"`                // Indica a situação dos lançamentos a serem pesquisados. Valor default é 'T'.
-	PrevisaoReal          *string  `json:"PrevisaoReal,omitempty"`          // Indica o tipo dos lançamentos a serem pesquisados. Valor default é 'T'.
-	NumeroDocumento       *string  `json:"NumeroDocumento,omitempty"`       // Número do documento do fornecedor.
-	UsuarioInclusao       *string  `json:"UsuarioInclusao,omitempty"`       // Usuário que incluiu o lançamento.
-	ValorLiquido          *float64 `json:"ValorLiquido,omitempty"`          // Valor líquido do lançamento. Valor default é '0'.
-	ValorBruto            *float64 `json:"ValorBruto,omitempty"`            // Valor bruto do lançamento. Valor default é '0'.
-	ValorPagamento        *float64 `json:"ValorPagamento,omitempty"`        // Valor do pagamento. Valor default é '0'.
-	QtdeLinhas            *int     `json:"QtdeLinhas,omitempty"`            // Quantidade máxima de linhas de resposta, utilizado para obter resultados por segmentos (paginação). Se não for informado então a resposta conterá todas as linhas selecionadas pela ação. Valor default é '0'.
-	ProximasLinhas        *string  `json:"ProximasLinhas,omitempty"`        // Campo opcional indicando que, ao invés de executar a ação, solicita as linhas do próximo segmento. Valor default é 'N'.
+	TipoPesquisa          *string               `json:"TipoPesquisa,omitempty" validate:"required"` // *Indica o tipo de pesquisa/origem.
+	CodCondominio         *int                  `json:"CodCondominio,omitempty"`                    // Código do condomínio do lançamento (se origem for 'C').
+	CodImovel             *int                  `json:"CodImovel,omitempty"`                        // Código do imóvel do lançamento (se origem for 'I').
+	CodPessoaProprietario *int                  `json:"CodPessoaProprietario,omitempty"`            // Código do proprietário.
+	CodPlanoContaAdm      *int                  `json:"CodPlanoContaAdm,omitempty"`                 // Código da conta no plano de contas da administradora (se origem for 'A').
+	CodBloco              *string               `json:"CodBloco,omitempty"`                         // Código do bloco do lançamento (se origem for 'C').
+	CodFornecedor         *int                  `json:"CodFornecedor,omitempty"`                    // Código do fornecedor do lançamento.
+	CodPessoaFavorecido   *int                  `json:"CodPessoaFavorecido,omitempty"`              // Código do favorecido no cadastro de pessoas.
+	NomeFavorecido        *string               `json:"NomeFavorecido,omitempty"`                   // Nome do favorecido.
+	GrupoSoma             *int                  `json:"GrupoSoma,omitempty"`                        // Código do grupo de soma.
+	CodTaxa               *int                  `json:"CodTaxa,omitempty"`                          // Código da taxa que classifica este lançamento.
+	TipoPeriodo           *string               `json:"TipoPeriodo,omitempty"`                      // Indica o tipo de período a ser pesquisado. Valor default é 'V'.
+	DataInicial           *imobdate.Date        `json:"DataInicial,omitempty"`                      // Primeiro dia do período a ser pesquisado.
+	DataFinal             *imobdate.Date        `json:"DataFinal,omitempty"`                        // Último dia do período a ser pesquisado.
+	Competencia           *imobdate.Competencia `json:"Competencia,omitempty"`                      // Competência do lançamento no formato 'YYYYMM'.
+	Status                *string               `json:"Status,omitempty"`                           // Indica a situação dos lançamentos a serem pesquisados. Valor default é 'T'.
+	PrevisaoReal          *enums.PrevisaoReal   `json:"PrevisaoReal,omitempty"`                     // Indica o tipo dos lançamentos a serem pesquisados. Valor default é 'T'.
+	NumeroDocumento       *string               `json:"NumeroDocumento,omitempty"`                  // Número do documento do fornecedor.
+	UsuarioInclusao       *string               `json:"UsuarioInclusao,omitempty"`                  // Usuário que incluiu o lançamento.
+	ValorLiquido          *money.Money          `json:"ValorLiquido,omitempty"`                     // Valor líquido do lançamento. Valor default é '0'.
+	ValorBruto            *money.Money          `json:"ValorBruto,omitempty"`                       // Valor bruto do lançamento. Valor default é '0'.
+	ValorPagamento        *money.Money          `json:"ValorPagamento,omitempty"`                   // Valor do pagamento. Valor default é '0'.
+	QtdeLinhas            *int                  `json:"QtdeLinhas,omitempty"`                       // Quantidade máxima de linhas de resposta, utilizado para obter resultados por segmentos (paginação). Se não for informado então a resposta conterá todas as linhas selecionadas pela ação. Valor default é '0'.
+	ProximasLinhas        *string               `json:"ProximasLinhas,omitempty"`                   // Campo opcional indicando que, ao invés de executar a ação, solicita as linhas do próximo segmento. Valor default é 'N'.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -131,6 +95,37 @@ func Run(input *RunInput) (*RunOutput, error) {
 	return (*RunOutput)(handlerOutput.Body), nil
 }
 
+// All percorre todos os resultados da pesquisa, buscando os segmentos de
+// QtdeLinhas linhas com ProximasLinhas até o fim.
+func All(ctx context.Context, sess *session.Session, input *ActionInput) iter.Seq2[RequestResponseBodyLancamento, error] {
+	actionInput := ActionInput{}
+	if input != nil {
+		actionInput = *input
+	}
+
+	qtdeLinhas := consts.QtdeLinhas(actionInput.QtdeLinhas)
+	actionInput.QtdeLinhas = &qtdeLinhas
+	actionInput.ProximasLinhas = nil
+
+	return consts.All(ctx, qtdeLinhas, func(ctx context.Context, proximas bool) ([]RequestResponseBodyLancamento, error) {
+		segmentInput := actionInput
+		if proximas {
+			proximasLinhas := consts.ProximasLinhas
+			segmentInput.ProximasLinhas = &proximasLinhas
+		}
+
+		output, err := RunContext(ctx, &RunInput{
+			Session:     sess,
+			ActionInput: &segmentInput,
+		})
+		if err != nil || output.Lancamentos == nil {
+			return nil, err
+		}
+
+		return *output.Lancamentos, nil
+	})
+}
+
 type HandlerInput struct {
 	Session *session.Session
 	*ActionInput
@@ -150,6 +145,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -171,65 +170,44 @@ type RequestResponseBody struct {
 }
 
 type RequestResponseBodyLancamento struct {
-	NumeroLancto     *int     `json:"NumeroLancto,omitempty"`     // Número do lançamento.
-	DataVencimento   *string  `json:"DataVencimento,omitempty"`   // Data de vencimento do lançamento.
-	CodTaxa          *int     `json:"CodTaxa,omitempty"`          // Código da taxa que classifica este lançamento.
-	DescrTaxa        *string  `json:"DescrTaxa,omitempty"`        // Descrição da taxa que classifica este lançamento.
-	NomeFavorecido   *string  `json:"NomeFavorecido,omitempty"`   // Nome do favorecido.
-	ValorLiquido     *float64 `json:"ValorLiquido,omitempty"`     // Valor líquido do lançamento.
-	PrevisaoReal     *string  `json:"PrevisaoReal,omitempty"`     // Indica o tipo dos lançamentos a serem pesquisados.
-	Frequencia       *string  `json:"Frequencia,omitempty"`       // Define se lançamento é único ou permanente.
-	Pago             *string  `json:"Pago,omitempty"`             // Indica se o lançamento está pago.
-	NumeroDocumento  *string  `json:"NumeroDocumento,omitempty"`  // Número do documento do fornecedor.
-	UsuarioSuspensao *string  `json:"UsuarioSuspensao,omitempty"` // Usuário que suspendeu o lançamento.
-	DataSuspensao    *string  `json:"DataSuspensao,omitempty"`    // Data da suspensão do lançamento.
-	MotivoSuspensao  *string  `json:"MotivoSuspensao,omitempty"`  // Motivo da suspensão do lançamento.
-	ValorPagamento   *float64 `json:"ValorPagamento,omitempty"`   // Valor do pagamento.
-}
-
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+	NumeroLancto     *int                `json:"NumeroLancto,omitempty"`     // Número do lançamento.
+	DataVencimento   *imobdate.Date      `json:"DataVencimento,omitempty"`   // Data de vencimento do lançamento.
+	CodTaxa          *int                `json:"CodTaxa,omitempty"`          // Código da taxa que classifica este lançamento.
+	DescrTaxa        *string             `json:"DescrTaxa,omitempty"`        // Descrição da taxa que classifica este lançamento.
+	NomeFavorecido   *string             `json:"NomeFavorecido,omitempty"`   // Nome do favorecido.
+	ValorLiquido     *money.Money        `json:"ValorLiquido,omitempty"`     // Valor líquido do lançamento.
+	PrevisaoReal     *enums.PrevisaoReal `json:"PrevisaoReal,omitempty"`     // Indica o tipo dos lançamentos a serem pesquisados.
+	Frequencia       *enums.Frequencia   `json:"Frequencia,omitempty"`       // Define se lançamento é único ou permanente.
+	Pago             *enums.Flag         `json:"Pago,omitempty"`             // Indica se o lançamento está pago.
+	NumeroDocumento  *string             `json:"NumeroDocumento,omitempty"`  // Número do documento do fornecedor.
+	UsuarioSuspensao *string             `json:"UsuarioSuspensao,omitempty"` // Usuário que suspendeu o lançamento.
+	DataSuspensao    *imobdate.Date      `json:"DataSuspensao,omitempty"`    // Data da suspensão do lançamento.
+	MotivoSuspensao  *string             `json:"MotivoSuspensao,omitempty"`  // Motivo da suspensão do lançamento.
+	ValorPagamento   *money.Money        `json:"ValorPagamento,omitempty"`   // Valor do pagamento.
+}
+
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
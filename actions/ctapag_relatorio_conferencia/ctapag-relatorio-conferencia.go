@@ -1,125 +1,89 @@
 package ctapag_relatorio_conferencia
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAPAG_RELATORIO_CONFERENCIA"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	Rotina                *string `json:"Rotina,omitempty"`                // *Seleciona qual rotina de lançamentos relacionar.
-	Competencia           *string `json:"Competencia,omitempty"`           // *Competência do relatório de conferência.
-	CodFilial             *int    `json:"CodFilial,omitempty"`             // Código da filial. Valor default é '000'.
-	DataVencimentoInicial *string `json:"DataVencimentoInicial,omitempty"` // Data de vencimento inicial.
-	DataVencimentoFinal   *string `json:"DataVencimentoFinal,omitempty"`   // Data de vencimento final.
-	CodFornecedor         *int    `json:"CodFornecedor,omitempty"`         // Código de fornecedor desta filial.
-	CodTaxa               *int    `json:"CodTaxa,omitempty"`               // Código da taxa que classifica este lançamento.
-	PagtoBanco            *string `json:"PagtoBanco,omitempty"`            // Listar lançamentos com forma de pagamento por banco (cheque). Valor default é 'N'.
-	PagtoCaixa            *string `json:"PagtoCaixa,omitempty"`            // Listar lançamentos com forma de pagamento por ciaxa (Cheque). Valor default é 'N'.
-	PagtoDinheiro         *string `json:"PagtoDinheiro,omitempty"`         // Listar lançamentos com forma de pagamento por dinheiro. Valor default é 'N'.
-	PagtoOrdem            *string `json:"PagtoOrdem,omitempty"`            // Listar lançamentos com forma de pagamento por ordem de pagamento. Valor default é 'N'.
-	PagtoLiqTitulos       *string `json:"PagtoLiqTitulos,omitempty"`       // Listar lançamentos com forma de pagamento por liquidação de títulos. Valor default é 'N'.
-	PagtoLiqTitulosAgrup  *string `json:"PagtoLiqTitulosAgrup,omitempty"`  // Listar lançamentos com forma de pagamento por liquidação de títulos agrupados. Valor default é 'N'.
-	PagtoPIXTransf        *string `json:"PagtoPIXTransf,omitempty"`        // Listar lançamentos com forma de pagamento por transferência de PIX. Valor default é 'N'.
-	PagtoPIXQrCode        *string `json:"PagtoPIXQrCode,omitempty"`        // Listar lançamentos com forma de pagamento por QRcode de PIX. Valor default é 'N'.
-	PagtoCredConta        *string `json:"PagtoCredConta,omitempty"`        // Listar lançamentos com forma de pagamento por crédito em conta. Valor default é 'N'.
-	PagtoDecAutomatico    *string `json:"PagtoDecAutomatico,omitempty"`    // Listar lançamentos com forma de pagamento por débito automático. Valor default é 'N'.
-	NaoPagar              *string `json:"NaoPagar,omitempty"`              // Listar somente lançamentos marcados para não pagar. Valor default é 'N'.
-	SoQuitados            *string `json:"SoQuitados,omitempty"`            // Listar somente lançamentos quitados. Valor default é 'N'.
-	SoComDiferenca        *string `json:"SoComDiferenca,omitempty"`        // Listar somente registros com diferença.
-	ResponseFormat        *string `json:"ResponseFormat,omitempty"`        // Formato desejado da resposta.
+	Rotina                *string               `json:"Rotina,omitempty" validate:"required"`      // *Seleciona qual rotina de lançamentos relacionar.
+	Competencia           *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"` // *Competência do relatório de conferência.
+	CodFilial             *int                  `json:"CodFilial,omitempty"`                       // Código da filial. Valor default é '000'.
+	DataVencimentoInicial *imobdate.Date        `json:"DataVencimentoInicial,omitempty"`           // Data de vencimento inicial.
+	DataVencimentoFinal   *imobdate.Date        `json:"DataVencimentoFinal,omitempty"`             // Data de vencimento final.
+	CodFornecedor         *int                  `json:"CodFornecedor,omitempty"`                   // Código de fornecedor desta filial.
+	CodTaxa               *int                  `json:"CodTaxa,omitempty"`                         // Código da taxa que classifica este lançamento.
+	PagtoBanco            *enums.Flag           `json:"PagtoBanco,omitempty"`                      // Listar lançamentos com forma de pagamento por banco (cheque). Valor default é 'N'.
+	PagtoCaixa            *enums.Flag           `json:"PagtoCaixa,omitempty"`                      // Listar lançamentos com forma de pagamento por ciaxa (Cheque). Valor default é 'N'.
+	PagtoDinheiro         *enums.Flag           `json:"PagtoDinheiro,omitempty"`                   // Listar lançamentos com forma de pagamento por dinheiro. Valor default é 'N'.
+	PagtoOrdem            *enums.Flag           `json:"PagtoOrdem,omitempty"`                      // Listar lançamentos com forma de pagamento por ordem de pagamento. Valor default é 'N'.
+	PagtoLiqTitulos       *enums.Flag           `json:"PagtoLiqTitulos,omitempty"`                 // Listar lançamentos com forma de pagamento por liquidação de títulos. Valor default é 'N'.
+	PagtoLiqTitulosAgrup  *enums.Flag           `json:"PagtoLiqTitulosAgrup,omitempty"`            // Listar lançamentos com forma de pagamento por liquidação de títulos agrupados. Valor default é 'N'.
+	PagtoPIXTransf        *enums.Flag           `json:"PagtoPIXTransf,omitempty"`                  // Listar lançamentos com forma de pagamento por transferência de PIX. Valor default é 'N'.
+	PagtoPIXQrCode        *enums.Flag           `json:"PagtoPIXQrCode,omitempty"`                  // Listar lançamentos com forma de pagamento por QRcode de PIX. Valor default é 'N'.
+	PagtoCredConta        *enums.Flag           `json:"PagtoCredConta,omitempty"`                  // Listar lançamentos com forma de pagamento por crédito em conta. Valor default é 'N'.
+	PagtoDecAutomatico    *enums.Flag           `json:"PagtoDecAutomatico,omitempty"`              // Listar lançamentos com forma de pagamento por débito automático. Valor default é 'N'.
+	NaoPagar              *enums.Flag           `json:"NaoPagar,omitempty"`                        // Listar somente lançamentos marcados para não pagar. Valor default é 'N'.
+	SoQuitados            *enums.Flag           `json:"SoQuitados,omitempty"`                      // Listar somente lançamentos quitados. Valor default é 'N'.
+	SoComDiferenca        *string               `json:"SoComDiferenca,omitempty"`                  // Listar somente registros com diferença.
+	ResponseFormat        *string               `json:"ResponseFormat,omitempty"`                  // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -147,6 +111,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -164,28 +132,28 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	Competencia   *string                           `json:"Competencia,omitempty"`   // Competência do relatório de conferência.
+	Competencia   *imobdate.Competencia             `json:"Competencia,omitempty"`   // Competência do relatório de conferência.
 	Lancamentos   *[]RequestResponseBodyLancamento  `json:"Lancamentos,omitempty"`   //
 	TotaisLanctos *RequestResponseBodyTotaisLanctos `json:"TotaisLanctos,omitempty"` //
 	Resumos       *[]RequestResponseBodyResumo      `json:"Resumos,omitempty"`       //
 }
 
 type RequestResponseBodyLancamento struct {
-	NumeroLancto   *int     `json:"NumeroLancto,omitempty"`   // Número do lançamento.
-	CodImovel      *int     `json:"CodImovel,omitempty"`      // Código do imóvel.
-	Descricao      *string  `json:"Descricao,omitempty"`      // Descrição do lançamento/item.
-	DataVencimento *string  `json:"DataVencimento,omitempty"` // Data de vencimento do lançamento.
-	DataPagamento  *string  `json:"DataPagamento,omitempty"`  // Data de pagamento do lançamento (quando quitado).
-	OrigemCobranca *string  `json:"OrigemCobranca,omitempty"` // Origem da cobrança do lançamento.
-	Valor          *float64 `json:"Valor,omitempty"`          // Valor do(s) lançamento(s).
-	ValorCobrado   *float64 `json:"ValorCobrado,omitempty"`   // Valor cobrado.
-	Diferenca      *float64 `json:"Diferenca,omitempty"`      // Diferença na cobrança do(s) lançamento(s).
+	NumeroLancto   *int                  `json:"NumeroLancto,omitempty"`   // Número do lançamento.
+	CodImovel      *int                  `json:"CodImovel,omitempty"`      // Código do imóvel.
+	Descricao      *string               `json:"Descricao,omitempty"`      // Descrição do lançamento/item.
+	DataVencimento *imobdate.Date        `json:"DataVencimento,omitempty"` // Data de vencimento do lançamento.
+	DataPagamento  *imobdate.Date        `json:"DataPagamento,omitempty"`  // Data de pagamento do lançamento (quando quitado).
+	OrigemCobranca *enums.OrigemCobranca `json:"OrigemCobranca,omitempty"` // Origem da cobrança do lançamento.
+	Valor          *money.Money          `json:"Valor,omitempty"`          // Valor do(s) lançamento(s).
+	ValorCobrado   *money.Money          `json:"ValorCobrado,omitempty"`   // Valor cobrado.
+	Diferenca      *money.Money          `json:"Diferenca,omitempty"`      // Diferença na cobrança do(s) lançamento(s).
 }
 
 type RequestResponseBodyTotaisLanctos struct {
-	Valor        *float64 `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
-	ValorCobrado *float64 `json:"ValorCobrado,omitempty"` // Valor cobrado.
-	Diferenca    *float64 `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
+	Valor        *money.Money `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
+	ValorCobrado *money.Money `json:"ValorCobrado,omitempty"` // Valor cobrado.
+	Diferenca    *money.Money `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
 }
 
 type RequestResponseBodyResumo struct {
@@ -195,63 +163,42 @@ type RequestResponseBodyResumo struct {
 }
 
 type RequestResponseBodyResumoItens struct {
-	Descricao    *string  `json:"Descricao,omitempty"`    // Descrição do lançamento/item.
-	Quantidade   *int     `json:"Quantidade,omitempty"`   // Quantidade de lançamentos/itens.
-	Valor        *float64 `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
-	ValorCobrado *float64 `json:"ValorCobrado,omitempty"` // Valor cobrado.
-	Diferenca    *float64 `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
+	Descricao    *string      `json:"Descricao,omitempty"`    // Descrição do lançamento/item.
+	Quantidade   *int         `json:"Quantidade,omitempty"`   // Quantidade de lançamentos/itens.
+	Valor        *money.Money `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
+	ValorCobrado *money.Money `json:"ValorCobrado,omitempty"` // Valor cobrado.
+	Diferenca    *money.Money `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
 }
 
 type RequestResponseBodyResumoTotaisResumo struct {
-	Quantidade   *int     `json:"Quantidade,omitempty"`   // Quantidade de lançamentos/itens.
-	Valor        *float64 `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
-	ValorCobrado *float64 `json:"ValorCobrado,omitempty"` // Valor cobrado.
-	Diferenca    *float64 `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
+	Quantidade   *int         `json:"Quantidade,omitempty"`   // Quantidade de lançamentos/itens.
+	Valor        *money.Money `json:"Valor,omitempty"`        // Valor do(s) lançamento(s).
+	ValorCobrado *money.Money `json:"ValorCobrado,omitempty"` // Valor cobrado.
+	Diferenca    *money.Money `json:"Diferenca,omitempty"`    // Diferença na cobrança do(s) lançamento(s).
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,112 +1,76 @@
 package ctapag_relatorio_slip
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAPAG_RELATORIO_SLIP"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodFilial                *int    `json:"CodFilial,omitempty"`                // Código da filial. Valor default é '000'.
-	DataPagamentoInicial     *string `json:"DataPagamentoInicial,omitempty"`     // *Data de pagamento inicial do período.
-	DataPagamentoFinal       *string `json:"DataPagamentoFinal,omitempty"`       // Data de pagamento final do período.
-	ResumoContabil           *string `json:"ResumoContabil,omitempty"`           // Listagem resumida. Valor default é 'N'.
-	LancamentosContaCorrente *string `json:"LancamentosContaCorrente,omitempty"` // Exibir lançamentos em conta corrente. Valor default é 'N'.
-	TipoLancamento           *string `json:"TipoLancamento,omitempty"`           // Tipo de lançamento. Valor default é 'R'.
-	DemonstrativoValores     *string `json:"DemonstrativoValores,omitempty"`     // Demonstrativo de valores. Valor default é 'C'.
-	ResponseFormat           *string `json:"ResponseFormat,omitempty"`           // Formato desejado da resposta.
+	CodFilial                *int           `json:"CodFilial,omitempty"`                                // Código da filial. Valor default é '000'.
+	DataPagamentoInicial     *imobdate.Date `json:"DataPagamentoInicial,omitempty" validate:"required"` // *Data de pagamento inicial do período.
+	DataPagamentoFinal       *imobdate.Date `json:"DataPagamentoFinal,omitempty"`                       // Data de pagamento final do período.
+	ResumoContabil           *enums.Flag    `json:"ResumoContabil,omitempty"`                           // Listagem resumida. Valor default é 'N'.
+	LancamentosContaCorrente *enums.Flag    `json:"LancamentosContaCorrente,omitempty"`                 // Exibir lançamentos em conta corrente. Valor default é 'N'.
+	TipoLancamento           *string        `json:"TipoLancamento,omitempty"`                           // Tipo de lançamento. Valor default é 'R'.
+	DemonstrativoValores     *string        `json:"DemonstrativoValores,omitempty"`                     // Demonstrativo de valores. Valor default é 'C'.
+	ResponseFormat           *string        `json:"ResponseFormat,omitempty"`                           // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -134,6 +98,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -151,77 +119,56 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	DataPagamentoInicial *string                       `json:"DataPagamentoInicial,omitempty"` // Data de pagamento inicial do período.
-	DataPagamentoFinal   *string                       `json:"DataPagamentoFinal,omitempty"`   // Data de pagamento final do período.
+	DataPagamentoInicial *imobdate.Date                `json:"DataPagamentoInicial,omitempty"` // Data de pagamento inicial do período.
+	DataPagamentoFinal   *imobdate.Date                `json:"DataPagamentoFinal,omitempty"`   // Data de pagamento final do período.
 	Origens              *[]RequestResponseBodyOrigem  `json:"Origens,omitempty"`              //
 	Destinos             *[]RequestResponseBodyDestino `json:"Destinos,omitempty"`             //
 	Totais               *RequestResponseBodyTotais    `json:"Totais,omitempty"`               //
 }
 
 type RequestResponseBodyOrigem struct {
-	CodConta     *int     `json:"CodConta,omitempty"`     // Código da conta recebida.
-	NomeConta    *string  `json:"NomeConta,omitempty"`    // Nome da conta recebida.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico da conta recebida.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crédito.
+	CodConta     *int         `json:"CodConta,omitempty"`     // Código da conta recebida.
+	NomeConta    *string      `json:"NomeConta,omitempty"`    // Nome da conta recebida.
+	Historico    *string      `json:"Historico,omitempty"`    // Histórico da conta recebida.
+	ValorDebito  *money.Money `json:"ValorDebito,omitempty"`  // Valor de débito.
+	ValorCredito *money.Money `json:"ValorCredito,omitempty"` // Valor de crédito.
 }
 
 type RequestResponseBodyDestino struct {
-	CodConta     *int     `json:"CodConta,omitempty"`     // Código da conta recebida.
-	NomeConta    *string  `json:"NomeConta,omitempty"`    // Nome da conta recebida.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico da conta recebida.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crédito.
+	CodConta     *int         `json:"CodConta,omitempty"`     // Código da conta recebida.
+	NomeConta    *string      `json:"NomeConta,omitempty"`    // Nome da conta recebida.
+	Historico    *string      `json:"Historico,omitempty"`    // Histórico da conta recebida.
+	ValorDebito  *money.Money `json:"ValorDebito,omitempty"`  // Valor de débito.
+	ValorCredito *money.Money `json:"ValorCredito,omitempty"` // Valor de crédito.
 }
 
 type RequestResponseBodyTotais struct {
-	TotalCreditos *float64 `json:"TotalCreditos,omitempty"` // Total de créditos.
-	TotalDebitos  *float64 `json:"TotalDebitos,omitempty"`  // Total de débitos.
+	TotalCreditos *money.Money `json:"TotalCreditos,omitempty"` // Total de créditos.
+	TotalDebitos  *money.Money `json:"TotalDebitos,omitempty"`  // Total de débitos.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,130 +1,94 @@
 package ctarec_boleto_acordo_calcular
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAREC_BOLETO_ACORDO_CALCULAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	DocCapaIds           *string               `json:"DocCapaIds,omitempty"`           // *Lista de códigos de boletos (DocCapaId) que devem entrar no acordo separados por virgula (,).
-	DataVencPrimeiraParc *string               `json:"DataVencPrimeiraParc,omitempty"` // *Data de vencimento da primeira parcela.
-	DataVencSegundaParc  *string               `json:"DataVencSegundaParc,omitempty"`  // Data de vencimento da segunda parcela.
-	QtdParcelas          *float64              `json:"QtdParcelas,omitempty"`          // *Quantidade de parcelas do acordo.
-	FormaLancto          *string               `json:"FormaLancto,omitempty"`          // *Forma de lançamento no sistema.
-	FormaCobranca        *string               `json:"FormaCobranca,omitempty"`        // *Forma de cobrança.
-	TipoCorrecao         *string               `json:"TipoCorrecao,omitempty"`         // *
-	TipoAcordo           *string               `json:"TipoAcordo,omitempty"`           // *Código de identificação do acordo.
-	Complemento          *string               `json:"Complemento,omitempty"`          // *Texto que identifica os boletos originais do acordo. Ex.: "Venctos 10/05/20yy a 10/08/20yy.".
-	VlrCustas            *float64              `json:"VlrCustas,omitempty"`            //
-	VlrHonorarios        *float64              `json:"VlrHonorarios,omitempty"`        //
-	PercHonorarios       *float64              `json:"PercHonorarios,omitempty"`       // Percentual de honorários (a ser dividido entre as parcelas do acordo).
-	VlrMulta             *float64              `json:"VlrMulta,omitempty"`             //
-	VlrMultaProp         *float64              `json:"VlrMultaProp,omitempty"`         //
-	VlrJuros             *float64              `json:"VlrJuros,omitempty"`             // Valor total de juros (a ser dividido entre as parcelas do acordo). Se não for informado, o sistema irá apurar conforme tempo de atraso dos boletos originais.
-	VlrCorrecao          *float64              `json:"VlrCorrecao,omitempty"`          // Valor total de correção (a ser dividido entre as parcelas do acordo). Se não for informado, o sistema irá apurar conforme tempo de atraso dos boletos originais.
-	PercJuros            *float64              `json:"PercJuros,omitempty"`            // Percentual de juros se atraso de boleto. Se não for informado, o sistema assumirá a cobrança tradicional de juros do condomínio.
-	PercMulta            *float64              `json:"PercMulta,omitempty"`            // Percentual de multa se atraso de boleto. Se não for informado, o sistema assumirá a cobrança tradicional de multa do condomínio.
-	HonorariosCC         *string               `json:"HonorariosCC,omitempty"`         // Indica se deverá ou não lançar honorários em conta corrente na quitação da parcela do acordo. Valor default é 'N'),TransactField(Honorarios_CC.
-	ExportarDoc          *string               `json:"ExportarDoc,omitempty"`          // *Indica se deverá ou não efetuar exportação dos boletos do acordo.
-	Parcelas             *[]ActionInputParcela `json:"Parcelas,omitempty"`             //
+	DocCapaIds           *string               `json:"DocCapaIds,omitempty" validate:"required"`           // *Lista de códigos de boletos (DocCapaId) que devem entrar no acordo separados por virgula (,).
+	DataVencPrimeiraParc *imobdate.Date        `json:"DataVencPrimeiraParc,omitempty" validate:"required"` // *Data de vencimento da primeira parcela.
+	DataVencSegundaParc  *imobdate.Date        `json:"DataVencSegundaParc,omitempty"`                      // Data de vencimento da segunda parcela.
+	QtdParcelas          *float64              `json:"QtdParcelas,omitempty" validate:"required"`          // *Quantidade de parcelas do acordo.
+	FormaLancto          *string               `json:"FormaLancto,omitempty" validate:"required"`          // *Forma de lançamento no sistema.
+	FormaCobranca        *string               `json:"FormaCobranca,omitempty" validate:"required"`        // *Forma de cobrança.
+	TipoCorrecao         *string               `json:"TipoCorrecao,omitempty" validate:"required"`         // *
+	TipoAcordo           *string               `json:"TipoAcordo,omitempty" validate:"required"`           // *Código de identificação do acordo.
+	Complemento          *string               `json:"Complemento,omitempty" validate:"required"`          // *Texto que identifica os boletos originais do acordo. Ex.: "Venctos 10/05/20yy a 10/08/20yy.".
+	VlrCustas            *money.Money          `json:"VlrCustas,omitempty"`                                //
+	VlrHonorarios        *money.Money          `json:"VlrHonorarios,omitempty"`                            //
+	PercHonorarios       *float64              `json:"PercHonorarios,omitempty"`                           // Percentual de honorários (a ser dividido entre as parcelas do acordo).
+	VlrMulta             *money.Money          `json:"VlrMulta,omitempty"`                                 //
+	VlrMultaProp         *money.Money          `json:"VlrMultaProp,omitempty"`                             //
+	VlrJuros             *money.Money          `json:"VlrJuros,omitempty"`                                 // Valor total de juros (a ser dividido entre as parcelas do acordo). Se não for informado, o sistema irá apurar conforme tempo de atraso dos boletos originais.
+	VlrCorrecao          *money.Money          `json:"VlrCorrecao,omitempty"`                              // Valor total de correção (a ser dividido entre as parcelas do acordo). Se não for informado, o sistema irá apurar conforme tempo de atraso dos boletos originais.
+	PercJuros            *float64              `json:"PercJuros,omitempty"`                                // Percentual de juros se atraso de boleto. Se não for informado, o sistema assumirá a cobrança tradicional de juros do condomínio.
+	PercMulta            *float64              `json:"PercMulta,omitempty"`                                // Percentual de multa se atraso de boleto. Se não for informado, o sistema assumirá a cobrança tradicional de multa do condomínio.
+	HonorariosCC         *enums.Flag           `json:"HonorariosCC,omitempty"`                             // Indica se deverá ou não lançar honorários em conta corrente na quitação da parcela do acordo. Valor default é 'N'),TransactField(Honorarios_CC.
+	ExportarDoc          *enums.Flag           `json:"ExportarDoc,omitempty" validate:"required"`          // *Indica se deverá ou não efetuar exportação dos boletos do acordo.
+	Parcelas             *[]ActionInputParcela `json:"Parcelas,omitempty"`                                 //
 }
 
 type ActionInputParcela struct {
-	Valor         *float64 `json:"Valor,omitempty"`         // *Valor de cada parcela.
-	VlrHonorarios *float64 `json:"VlrHonorarios,omitempty"` //
+	Valor         *money.Money `json:"Valor,omitempty" validate:"required"` // *Valor de cada parcela.
+	VlrHonorarios *money.Money `json:"VlrHonorarios,omitempty"`             //
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -152,6 +116,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -173,59 +141,38 @@ type RequestResponseBody struct {
 }
 
 type RequestResponseBodyBoleto struct {
-	DataVenc      *string  `json:"DataVenc,omitempty"`      // Data de vencimento do boleto.
-	TipoDOC       *string  `json:"TipoDOC,omitempty"`       // Tipo de boleto/DOC.
-	Complemento   *string  `json:"Complemento,omitempty"`   // Texto que identifica os boletos originais do acordo. Ex.: "Venctos 10/05/20yy a 10/08/20yy.".
-	Valor         *float64 `json:"Valor,omitempty"`         // Valor de cada parcela.
-	VlrHonorarios *float64 `json:"VlrHonorarios,omitempty"` //
-	VlrCustas     *float64 `json:"VlrCustas,omitempty"`     //
-	VlrMulta      *float64 `json:"VlrMulta,omitempty"`      //
-	VlrMultaProp  *float64 `json:"VlrMultaProp,omitempty"`  //
+	DataVenc      *imobdate.Date `json:"DataVenc,omitempty"`      // Data de vencimento do boleto.
+	TipoDOC       *string        `json:"TipoDOC,omitempty"`       // Tipo de boleto/DOC.
+	Complemento   *string        `json:"Complemento,omitempty"`   // Texto que identifica os boletos originais do acordo. Ex.: "Venctos 10/05/20yy a 10/08/20yy.".
+	Valor         *money.Money   `json:"Valor,omitempty"`         // Valor de cada parcela.
+	VlrHonorarios *money.Money   `json:"VlrHonorarios,omitempty"` //
+	VlrCustas     *money.Money   `json:"VlrCustas,omitempty"`     //
+	VlrMulta      *money.Money   `json:"VlrMulta,omitempty"`      //
+	VlrMultaProp  *money.Money   `json:"VlrMultaProp,omitempty"`  //
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
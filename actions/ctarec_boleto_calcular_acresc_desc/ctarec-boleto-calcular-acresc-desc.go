@@ -1,107 +1,71 @@
 package ctarec_boleto_calcular_acresc_desc
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAREC_BOLETO_CALCULAR_ACRESC_DESC"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	NossoNumero     *string `json:"NossoNumero,omitempty"`     // Número de identificação bancário.
-	DocCapaId       *int    `json:"DocCapaId,omitempty"`       // Código do boleto no sistema.
-	DataLimitePagto *string `json:"DataLimitePagto,omitempty"` // Data limite de pagamento.
+	NossoNumero     *string        `json:"NossoNumero,omitempty"`     // Número de identificação bancário.
+	DocCapaId       *int           `json:"DocCapaId,omitempty"`       // Código do boleto no sistema.
+	DataLimitePagto *imobdate.Date `json:"DataLimitePagto,omitempty"` // Data limite de pagamento.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -129,6 +93,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -146,66 +114,45 @@ type RequestResponseHeader struct {
 }
 
 type RequestResponseBody struct {
-	VlrDocumento     *float64 `json:"VlrDocumento,omitempty"`     // Valor do documento sem o seguro conteúdo, multa e juros.
-	VlrDocumentoOrig *float64 `json:"VlrDocumentoOrig,omitempty"` // Valor original do documento.
-	Juros            *float64 `json:"Juros,omitempty"`            // Valor de juros do documento.
-	Multa            *float64 `json:"Multa,omitempty"`            // Valor de multa do documento.
-	VlrCorrecao      *float64 `json:"VlrCorrecao,omitempty"`      // Valor de correçao do documento.
-	MultaProp        *float64 `json:"MultaProp,omitempty"`        // Valor de multa do proprietário.
-	VlrCorrecaoProp  *float64 `json:"VlrCorrecaoProp,omitempty"`  // Valor de correçao do proprietário.
-	DescontoProp     *float64 `json:"DescontoProp,omitempty"`     // Valor de desconto do proprietário.
-	DescontoAdm      *float64 `json:"DescontoAdm,omitempty"`      // Valor de desconto da administradora.
-	VlrCorrigido     *float64 `json:"VlrCorrigido,omitempty"`     // Valor corrigido do documento.
-	NossoNumero      *string  `json:"NossoNumero,omitempty"`      // Número de identificação bancário.
-	DocCapaId        *int     `json:"DocCapaId,omitempty"`        // Código do boleto no sistema.
-	OrigemCobranca   *string  `json:"OrigemCobranca,omitempty"`   // Locação/Condominio.
-	VlrSegCont       *float64 `json:"VlrSegCont,omitempty"`       // Valor do seguro conteúdo.
-	ErroIndice       *string  `json:"ErroIndice,omitempty"`       // Mensagem de erro.
-}
-
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+	VlrDocumento     *money.Money          `json:"VlrDocumento,omitempty"`     // Valor do documento sem o seguro conteúdo, multa e juros.
+	VlrDocumentoOrig *money.Money          `json:"VlrDocumentoOrig,omitempty"` // Valor original do documento.
+	Juros            *money.Money          `json:"Juros,omitempty"`            // Valor de juros do documento.
+	Multa            *money.Money          `json:"Multa,omitempty"`            // Valor de multa do documento.
+	VlrCorrecao      *money.Money          `json:"VlrCorrecao,omitempty"`      // Valor de correçao do documento.
+	MultaProp        *money.Money          `json:"MultaProp,omitempty"`        // Valor de multa do proprietário.
+	VlrCorrecaoProp  *money.Money          `json:"VlrCorrecaoProp,omitempty"`  // Valor de correçao do proprietário.
+	DescontoProp     *money.Money          `json:"DescontoProp,omitempty"`     // Valor de desconto do proprietário.
+	DescontoAdm      *money.Money          `json:"DescontoAdm,omitempty"`      // Valor de desconto da administradora.
+	VlrCorrigido     *money.Money          `json:"VlrCorrigido,omitempty"`     // Valor corrigido do documento.
+	NossoNumero      *string               `json:"NossoNumero,omitempty"`      // Número de identificação bancário.
+	DocCapaId        *int                  `json:"DocCapaId,omitempty"`        // Código do boleto no sistema.
+	OrigemCobranca   *enums.OrigemCobranca `json:"OrigemCobranca,omitempty"`   // Locação/Condominio.
+	VlrSegCont       *money.Money          `json:"VlrSegCont,omitempty"`       // Valor do seguro conteúdo.
+	ErroIndice       *string               `json:"ErroIndice,omitempty"`       // Mensagem de erro.
+}
+
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,110 +1,75 @@
 package ctarec_boleto_inadimplente_2via
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAREC_BOLETO_INADIMPLENTE_2VIA"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	NossoNumero          *string  `json:"NossoNumero,omitempty"`          // *Número de identificação bancário.
-	DataLimitePagamento  *string  `json:"DataLimitePagamento,omitempty"`  // *Data limite de pagamento do documento.
-	NroDiasIniVencto     *float64 `json:"NroDiasIniVencto,omitempty"`     //
-	NroDiasFimVencto     *float64 `json:"NroDiasFimVencto,omitempty"`     //
-	Email                *string  `json:"Email,omitempty"`                // E-mail da pessoa.
-	InibirCobrRegistrada *string  `json:"InibirCobrRegistrada,omitempty"` // Valor default é 'N'.
+	NossoNumero          *string        `json:"NossoNumero,omitempty" validate:"required"`         // *Número de identificação bancário.
+	DataLimitePagamento  *imobdate.Date `json:"DataLimitePagamento,omitempty" validate:"required"` // *Data limite de pagamento do documento.
+	NroDiasIniVencto     *float64       `json:"NroDiasIniVencto,omitempty"`                        //
+	NroDiasFimVencto     *float64       `json:"NroDiasFimVencto,omitempty"`                        //
+	Email                *string        `json:"Email,omitempty"`                                   // E-mail da pessoa.
+	InibirCobrRegistrada *enums.Flag    `json:"InibirCobrRegistrada,omitempty"`                    // Valor default é 'N'.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -132,6 +97,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -150,25 +119,25 @@ type RequestResponseHeader struct {
 
 type RequestResponseBody struct {
 	DocCapaId               *int                              `json:"DocCapaId,omitempty"`               // Código do boleto no sistema.
-	DOCRetido               *string                           `json:"DOCRetido,omitempty"`               // Indica se é um boleto/DOC retido.
+	DOCRetido               *enums.Flag                       `json:"DOCRetido,omitempty"`               // Indica se é um boleto/DOC retido.
 	CodPessoa               *int                              `json:"CodPessoa,omitempty"`               // Código de pessoa do sacado.
 	CobrRegAcordoVerParcAnt *string                           `json:"CobrRegAcordoVerParcAnt,omitempty"` //
 	IdAcordo                *int                              `json:"IdAcordo,omitempty"`                // Código de identificação do acordo.
-	DataVencAcordo          *string                           `json:"DataVencAcordo,omitempty"`          // Data de vencimento do acordo.
+	DataVencAcordo          *imobdate.Date                    `json:"DataVencAcordo,omitempty"`          // Data de vencimento do acordo.
 	FilialNome              *string                           `json:"FilialNome,omitempty"`              // Nome da filial.
 	FilialEnd               *string                           `json:"FilialEnd,omitempty"`               // Endereço da filial.
-	FilialCnpj              *int                              `json:"FilialCnpj,omitempty"`              // Cnpj da filial.
-	DataVenc                *string                           `json:"DataVenc,omitempty"`                // Data de vencimento do boleto.
+	FilialCnpj              *doc.CPFCNPJ                      `json:"FilialCnpj,omitempty"`              // Cnpj da filial.
+	DataVenc                *imobdate.Date                    `json:"DataVenc,omitempty"`                // Data de vencimento do boleto.
 	FilialCidade            *string                           `json:"FilialCidade,omitempty"`            // Cidade da filial.
 	IdCodBanco              *string                           `json:"IdCodBanco,omitempty"`              // Código do banco com dígito verificador.
 	LinhaDigitavel          *string                           `json:"LinhaDigitavel,omitempty"`          // Linha digitável do boleto.
-	VlrDocumento            *float64                          `json:"VlrDocumento,omitempty"`            // Valor do documento.
+	VlrDocumento            *money.Money                      `json:"VlrDocumento,omitempty"`            // Valor do documento.
 	NossoNumeroOrig         *string                           `json:"NossoNumeroOrig,omitempty"`         // Nosso Numero original.
 	LocalPagamento          *string                           `json:"LocalPagamento,omitempty"`          // Local de pagamento.
 	NomeCedente             *string                           `json:"NomeCedente,omitempty"`             // Nome do cedente.
 	CodCedente              *string                           `json:"CodCedente,omitempty"`              // Código do cedente.
-	DataDocumento           *string                           `json:"DataDocumento,omitempty"`           // Data do documento.
-	DataProcessamento       *string                           `json:"DataProcessamento,omitempty"`       // Data de processamento.
+	DataDocumento           *imobdate.Date                    `json:"DataDocumento,omitempty"`           // Data do documento.
+	DataProcessamento       *imobdate.Date                    `json:"DataProcessamento,omitempty"`       // Data de processamento.
 	NumeroDOC               *string                           `json:"NumeroDOC,omitempty"`               // Número do documento.
 	NossoNumero             *string                           `json:"NossoNumero,omitempty"`             // Número de identificação bancário.
 	Carteira                *string                           `json:"Carteira,omitempty"`                // Carteira bancária.
@@ -176,17 +145,17 @@ type RequestResponseBody struct {
 	Aceite                  *string                           `json:"Aceite,omitempty"`                  // Aceite do documento.
 	UsoBanco                *string                           `json:"UsoBanco,omitempty"`                // Informações de uso do banco.
 	Moeda                   *string                           `json:"Moeda,omitempty"`                   // Moeda do documento.
-	VlrAcrescOutr           *float64                          `json:"VlrAcrescOutr,omitempty"`           // Valor de outros acréscimos.
-	VlrDesconto             *string                           `json:"VlrDesconto,omitempty"`             // Valor de desconto.
-	VlrDescOutr             *float64                          `json:"VlrDescOutr,omitempty"`             // Valor de outros descontos.
-	VlrMulta                *string                           `json:"VlrMulta,omitempty"`                // Valor da multa mais juros.
-	VlrSegCont              *float64                          `json:"VlrSegCont,omitempty"`              // Valor do seguro conteúdo.
+	VlrAcrescOutr           *money.Money                      `json:"VlrAcrescOutr,omitempty"`           // Valor de outros acréscimos.
+	VlrDesconto             *money.MoneyTexto                 `json:"VlrDesconto,omitempty"`             // Valor de desconto.
+	VlrDescOutr             *money.Money                      `json:"VlrDescOutr,omitempty"`             // Valor de outros descontos.
+	VlrMulta                *money.MoneyTexto                 `json:"VlrMulta,omitempty"`                // Valor da multa mais juros.
+	VlrSegCont              *money.Money                      `json:"VlrSegCont,omitempty"`              // Valor do seguro conteúdo.
 	Sacado1                 *string                           `json:"Sacado1,omitempty"`                 // Primeira linha de informações do sacado.
 	Sacado2                 *string                           `json:"Sacado2,omitempty"`                 // Segunda linha de informações do sacado.
 	Sacado3                 *string                           `json:"Sacado3,omitempty"`                 // Terceira linha de informações do sacado.
 	CodBarras               *string                           `json:"CodBarras,omitempty"`               // Código de barras do boleto.
 	Aviso                   *string                           `json:"Aviso,omitempty"`                   // Aviso do documento.
-	DataLimitePagamento     *string                           `json:"DataLimitePagamento,omitempty"`     // Data limite de pagamento do documento.
+	DataLimitePagamento     *imobdate.Date                    `json:"DataLimitePagamento,omitempty"`     // Data limite de pagamento do documento.
 	Instrucoes              *[]RequestResponseBodyInstrucao   `json:"Instrucoes,omitempty"`              //
 	Detalhes                *[]RequestResponseBodyDetalhe     `json:"Detalhes,omitempty"`                //
 	Informativos            *[]RequestResponseBodyInformativo `json:"Informativos,omitempty"`            //
@@ -206,49 +175,28 @@ type RequestResponseBodyCabecalho struct {
 	Cabecalho *string `json:"Cabecalho,omitempty"` // Linha de cabeçalho.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
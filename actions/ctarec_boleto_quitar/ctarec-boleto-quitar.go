@@ -1,123 +1,87 @@
 package ctarec_boleto_quitar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "CTAREC_BOLETO_QUITAR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	OrigemCobranca            *string  `json:"OrigemCobranca,omitempty"`            // *Locação/Condominio.
-	NossoNumero               *string  `json:"NossoNumero,omitempty"`               // *Número de identificação bancário.
-	DocCapaId                 *int     `json:"DocCapaId,omitempty"`                 // *Código do boleto no sistema.
-	DataPagamento             *string  `json:"DataPagamento,omitempty"`             // *Data do pagamento.
-	OrigemQuitacao            *string  `json:"OrigemQuitacao,omitempty"`            // *Origem.
-	VlrJuros                  *float64 `json:"VlrJuros,omitempty"`                  // Valor dos juros.
-	VlrMulta                  *float64 `json:"VlrMulta,omitempty"`                  // Valor da multa.
-	VlrMultaAdministrativa    *float64 `json:"VlrMultaAdministrativa,omitempty"`    // *Valor multa administrativa.
-	VlrDescontoAdministrativo *float64 `json:"VlrDescontoAdministrativo,omitempty"` // *Valor desconto administrativo.
-	VlrAcrescimoOutros        *float64 `json:"VlrAcrescimoOutros,omitempty"`        // *Valor de acrescimos extras (não incluir multa e ou juros).
-	VlrDescontoProprietario   *float64 `json:"VlrDescontoProprietario,omitempty"`   // *Valor do desconto concedido pelo proprietario.
-	VlrDescontos              *float64 `json:"VlrDescontos,omitempty"`              // *Valor total dos descontos.
-	SeguroConteudo            *string  `json:"SeguroConteudo,omitempty"`            // Pagou o seguro conteúdo. Valor default é 'N'.
-	VlrAcrescimos             *float64 `json:"VlrAcrescimos,omitempty"`             // *Valor total dos acrescimos (multa e juros) mais o valor seguro conteúdo.
-	VlrPagamento              *float64 `json:"VlrPagamento,omitempty"`              // *Valor pago.
-	CodBanco                  *int     `json:"CodBanco,omitempty"`                  // *Código do banco.
-	Complemento               *string  `json:"Complemento,omitempty"`               // *
-	IdAdmCCDeposito           *int     `json:"IdAdmCCDeposito,omitempty"`           // Id interno da conta corrente que recebeu o deposito.
-	DataDeposito              *string  `json:"DataDeposito,omitempty"`              // Data do deposito no banco.
+	OrigemCobranca            *enums.OrigemCobranca `json:"OrigemCobranca,omitempty" validate:"required"`            // *Locação/Condominio.
+	NossoNumero               *string               `json:"NossoNumero,omitempty" validate:"required"`               // *Número de identificação bancário.
+	DocCapaId                 *int                  `json:"DocCapaId,omitempty" validate:"required"`                 // *Código do boleto no sistema.
+	DataPagamento             *imobdate.Date        `json:"DataPagamento,omitempty" validate:"required"`             // *Data do pagamento.
+	OrigemQuitacao            *string               `json:"OrigemQuitacao,omitempty" validate:"required"`            // *Origem.
+	VlrJuros                  *money.Money          `json:"VlrJuros,omitempty"`                                      // Valor dos juros.
+	VlrMulta                  *money.Money          `json:"VlrMulta,omitempty"`                                      // Valor da multa.
+	VlrMultaAdministrativa    *money.Money          `json:"VlrMultaAdministrativa,omitempty" validate:"required"`    // *Valor multa administrativa.
+	VlrDescontoAdministrativo *money.Money          `json:"VlrDescontoAdministrativo,omitempty" validate:"required"` // *Valor desconto administrativo.
+	VlrAcrescimoOutros        *money.Money          `json:"VlrAcrescimoOutros,omitempty" validate:"required"`        // *Valor de acrescimos extras (não incluir multa e ou juros).
+	VlrDescontoProprietario   *money.Money          `json:"VlrDescontoProprietario,omitempty" validate:"required"`   // *Valor do desconto concedido pelo proprietario.
+	VlrDescontos              *money.Money          `json:"VlrDescontos,omitempty" validate:"required"`              // *Valor total dos descontos.
+	SeguroConteudo            *enums.Flag           `json:"SeguroConteudo,omitempty"`                                // Pagou o seguro conteúdo. Valor default é 'N'.
+	VlrAcrescimos             *money.Money          `json:"VlrAcrescimos,omitempty" validate:"required"`             // *Valor total dos acrescimos (multa e juros) mais o valor seguro conteúdo.
+	VlrPagamento              *money.Money          `json:"VlrPagamento,omitempty" validate:"required"`              // *Valor pago.
+	CodBanco                  *int                  `json:"CodBanco,omitempty" validate:"required"`                  // *Código do banco.
+	Complemento               *string               `json:"Complemento,omitempty" validate:"required"`               // *
+	IdAdmCCDeposito           *int                  `json:"IdAdmCCDeposito,omitempty"`                               // Id interno da conta corrente que recebeu o deposito.
+	DataDeposito              *imobdate.Date        `json:"DataDeposito,omitempty"`                                  // Data do deposito no banco.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -145,6 +109,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -171,49 +139,28 @@ type RequestResponseBodyLancamento struct {
 	NumeroLancto *int `json:"NumeroLancto,omitempty"` // Número do lançamento.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
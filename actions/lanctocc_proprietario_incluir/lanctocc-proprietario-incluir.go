@@ -1,128 +1,92 @@
 package lanctocc_proprietario_incluir
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LANCTOCC_PROPRIETARIO_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	CodPessoaProprietario *int     `json:"CodPessoaProprietario,omitempty"` // *Código do proprietário.
-	DcCcImovel            *string  `json:"DcCcImovel,omitempty"`            // Débito ou crédito na conta corrente do imóvel. Valor default é ' '.
-	DcReciboProprietario  *string  `json:"DcReciboProprietario,omitempty"`  // Débito ou crédito no recibo de proprietário. Valor default é ' '.
-	NoDemonstrativo       *string  `json:"NoDemonstrativo,omitempty"`       // Indicação da forma de lançamento no demonstrativo. Valor default é 'S'.
-	CodFilial             *string  `json:"CodFilial,omitempty"`             // *Código da filial do lançamento.
-	Competencia           *string  `json:"Competencia,omitempty"`           // *Competência do lançamento no formato 'YYYYMM'.
-	DataPagamento         *string  `json:"DataPagamento,omitempty"`         // *Data de pagamento do lançamento (quando quitado).
-	TipoDocumento         *string  `json:"TipoDocumento,omitempty"`         // *Tipo de documento do lançamento.
-	NumeroDocumento       *string  `json:"NumeroDocumento,omitempty"`       // *Número do documento do fornecedor.
-	Complemento           *string  `json:"Complemento,omitempty"`           // Complemento descritivo do lançamento.
-	ComplementoAdicional1 *string  `json:"ComplementoAdicional1,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional2 *string  `json:"ComplementoAdicional2,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional3 *string  `json:"ComplementoAdicional3,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional4 *string  `json:"ComplementoAdicional4,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional5 *string  `json:"ComplementoAdicional5,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional6 *string  `json:"ComplementoAdicional6,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional7 *string  `json:"ComplementoAdicional7,omitempty"` // Informação de complemento extra.
-	ComplementoAdicional8 *string  `json:"ComplementoAdicional8,omitempty"` // Informação de complemento extra.
-	CodContraPartida      *int     `json:"CodContraPartida,omitempty"`      // Código da conta de contra partida cadastrada no plano de contas da administradora.
-	LancaNaViradaParcelas *string  `json:"LancaNaViradaParcelas,omitempty"` // *Lançamento automático na virada de parcelas.
-	NumeroParcela         *int     `json:"NumeroParcela,omitempty"`         // Número da parcela do lançamento. Valor default é '1'.
-	TotalParcelas         *int     `json:"TotalParcelas,omitempty"`         // Quantidade total de parcelas. Valor default é '1'.
-	CodTaxa               *int     `json:"CodTaxa,omitempty"`               // *Código da taxa que classifica este lançamento.
-	Valor                 *float64 `json:"Valor,omitempty"`                 // *Valor total do lançamento.
+	CodPessoaProprietario *int                  `json:"CodPessoaProprietario,omitempty" validate:"required"` // *Código do proprietário.
+	DcCcImovel            *string               `json:"DcCcImovel,omitempty"`                                // Débito ou crédito na conta corrente do imóvel. Valor default é ' '.
+	DcReciboProprietario  *string               `json:"DcReciboProprietario,omitempty"`                      // Débito ou crédito no recibo de proprietário. Valor default é ' '.
+	NoDemonstrativo       *string               `json:"NoDemonstrativo,omitempty"`                           // Indicação da forma de lançamento no demonstrativo. Valor default é 'S'.
+	CodFilial             *string               `json:"CodFilial,omitempty" validate:"required"`             // *Código da filial do lançamento.
+	Competencia           *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"`           // *Competência do lançamento no formato 'YYYYMM'.
+	DataPagamento         *imobdate.Date        `json:"DataPagamento,omitempty"`                             // *Data de pagamento do lançamento (quando quitado).
+	TipoDocumento         *enums.TipoDocumento  `json:"TipoDocumento,omitempty" validate:"required"`         // *Tipo de documento do lançamento.
+	NumeroDocumento       *string               `json:"NumeroDocumento,omitempty" validate:"required"`       // *Número do documento do fornecedor.
+	Complemento           *string               `json:"Complemento,omitempty"`                               // Complemento descritivo do lançamento.
+	ComplementoAdicional1 *string               `json:"ComplementoAdicional1,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional2 *string               `json:"ComplementoAdicional2,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional3 *string               `json:"ComplementoAdicional3,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional4 *string               `json:"ComplementoAdicional4,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional5 *string               `json:"ComplementoAdicional5,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional6 *string               `json:"ComplementoAdicional6,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional7 *string               `json:"ComplementoAdicional7,omitempty"`                     // Informação de complemento extra.
+	ComplementoAdicional8 *string               `json:"ComplementoAdicional8,omitempty"`                     // Informação de complemento extra.
+	CodContraPartida      *int                  `json:"CodContraPartida,omitempty"`                          // Código da conta de contra partida cadastrada no plano de contas da administradora.
+	LancaNaViradaParcelas *string               `json:"LancaNaViradaParcelas,omitempty" validate:"required"` // *Lançamento automático na virada de parcelas.
+	NumeroParcela         *int                  `json:"NumeroParcela,omitempty"`                             // Número da parcela do lançamento. Valor default é '1'.
+	TotalParcelas         *int                  `json:"TotalParcelas,omitempty"`                             // Quantidade total de parcelas. Valor default é '1'.
+	CodTaxa               *int                  `json:"CodTaxa,omitempty" validate:"required"`               // *Código da taxa que classifica este lançamento.
+	Valor                 *money.Money          `json:"Valor,omitempty" validate:"required"`                 // *Valor total do lançamento.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -150,6 +114,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -170,49 +138,28 @@ type RequestResponseBody struct {
 	NumeroLancto *int `json:"NumeroLancto,omitempty"` // Número do lançamento.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
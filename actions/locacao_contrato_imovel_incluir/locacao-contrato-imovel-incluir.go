@@ -1,170 +1,134 @@
 package locacao_contrato_imovel_incluir
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_CONTRATO_IMOVEL_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	CodImovel                 *int                                 `json:"CodImovel,omitempty"`                 // *Código do imóvel.
-	CodIntegracaoSist         *string                              `json:"CodIntegracaoSist,omitempty"`         // Código deste contrato de locação no sistema integrado/migrado.
-	DataAssinatura            *string                              `json:"DataAssinatura,omitempty"`            // *Data de assinatura do contrato.
-	CodPessoaLocat            *int                                 `json:"CodPessoaLocat,omitempty"`            // *Código de pessoa do locatário principal.
-	DataVigInicial            *string                              `json:"DataVigInicial,omitempty"`            // *Data inicial da vigência do contrato.
-	DataVigFinal              *string                              `json:"DataVigFinal,omitempty"`              // *Data final da vigência do contrato.
-	DataProxReaj              *string                              `json:"DataProxReaj,omitempty"`              // Data do próximo reajuste.
-	ValorAluguel              *float64                             `json:"ValorAluguel,omitempty"`              // *Valor do aluguel inicial.
-	DataVencFianca            *string                              `json:"DataVencFianca,omitempty"`            // *Data de vencimento do seguro fiança.
-	DescricaoUso              *string                              `json:"DescricaoUso,omitempty"`              // *Descrição de qual será a utilização do imóvel.
-	CodAgencia                *int                                 `json:"CodAgencia,omitempty"`                // *Código da agência/loja a qual este contrato pertence.
-	PeriodoReajAluguel        *int                                 `json:"PeriodoReajAluguel,omitempty"`        // *Indica o número de meses em que o aluguel sofrerá reajuste, ou seja, de quantos em quantos meses ele será reajustado.
-	Prazo                     *int                                 `json:"Prazo,omitempty"`                     // *Prazo do contrato em meses.
-	PercMulta                 *float64                             `json:"PercMulta,omitempty"`                 // *Percentual de multa em caso de atraso de pagamento.
-	PercJuros                 *float64                             `json:"PercJuros,omitempty"`                 // *Percentual de juros em caso de atraso de pagamento.
-	Carencia                  *string                              `json:"Carencia,omitempty"`                  // *Indica se o locatário possui um período de carência inicial.
-	MesesCarencia             *int                                 `json:"MesesCarencia,omitempty"`             // Indica quantos meses de carência é dado ao locatário.
-	DiasCarencia              *int                                 `json:"DiasCarencia,omitempty"`              // Indica quantos dias de carência é dado ao locatário. Se for em percentual então informar no campo 'PercCarencia' mas apenas um deles deve ser informado.
-	PercCarencia              *float64                             `json:"PercCarencia,omitempty"`              // Indica o percentual de carência que é dado ao locatário dentro do mês. Se for em número de dias então informar no campo 'DiasCarencia' mas apenas um deles deve ser informado.
-	DescPontualidade          *string                              `json:"DescPontualidade,omitempty"`          // *Indica se tem desconto de pontualidade quando pago antes do vencimento.
-	PercDescPontualidade      *float64                             `json:"PercDescPontualidade,omitempty"`      // Percentual de desconto pontualidade.
-	ValorDescPontualidade     *float64                             `json:"ValorDescPontualidade,omitempty"`     // Valor fixo em Reais de desconto pontualidade, caso não se utilize um percentual de desconto.
-	DiasDescPontualidade      *int                                 `json:"DiasDescPontualidade,omitempty"`      // Número mínimo de dias de antecipação do pagamento para habilitar o desconto pontualidade.
-	FormaCalcPagto            *string                              `json:"FormaCalcPagto,omitempty"`            // *Indica a forma de cálculo para o pagamento ao proprietário.
-	DiaPagtoProp              *int                                 `json:"DiaPagtoProp,omitempty"`              // *Dia do mês para o pagamento ao proprietário quando a forma de cálculo for 'Programado'.
-	DiaVenctoDOC              *int                                 `json:"DiaVenctoDOC,omitempty"`              // *Dia do mês para vencimento do DOC de aluguel.
-	IsentaIR                  *string                              `json:"IsentaIR,omitempty"`                  // *Indica se o aluguel é isento de imposto de renda.
-	IsentaDescontoValorBaseIR *string                              `json:"IsentaDescontoValorBaseIR,omitempty"` // Indica se é isento do desconto do valor base do imposto de renda.
-	DocPorPeriodo             *string                              `json:"DocPorPeriodo,omitempty"`             // *Indica se o aluguel é por período determinado.
-	LocacaoTemporada          *string                              `json:"Locacao_Temporada,omitempty"`         // *Indica se o aluguel é por temporada.
-	IsentaTaxaPorte           *string                              `json:"IsentaTaxaPorte,omitempty"`           // *Indica se deve isentar da taxa porte.
-	IsentaTarifaDOC           *string                              `json:"IsentaTarifaDOC,omitempty"`           // *Indica se deve isentar da tarifa DOC.
-	DOCEmail                  *string                              `json:"DOC_Email,omitempty"`                 // *Indica se o DOC deve ser enviado por E-mail.
-	GaranteDOC                *string                              `json:"GaranteDOC,omitempty"`                // *Indica se utiliza a modalidade de DOC garantido.
-	ValorTarifaDOC            *float64                             `json:"ValorTarifaDOC,omitempty"`            // *Valor da tarifa DOC, caso não seja o valor default do sistema.
-	PercReajAluguel           *float64                             `json:"PercReajAluguel,omitempty"`           // *Percentual para correção do valor de aluguel, caso não se utilize um índice de reajuste.
-	ValorTxIntermed           *float64                             `json:"ValorTxIntermed,omitempty"`           // *Valor da taxa de intermediação.
-	CompetIniIntermed         *string                              `json:"CompetIniIntermed,omitempty"`         // *Competencia da cobrança inicial da taxa de intermediação no formato 'YYYYMM'.
-	NrParcIntermed            *int                                 `json:"NrParcIntermed,omitempty"`            // *Número de parcelas para pagamento da intermediação.
-	TipoAditamento            *string                              `json:"TipoAditamento,omitempty"`            // Tipo do aditamento.
-	IndiceReajAluguel         *string                              `json:"IndiceReajAluguel,omitempty"`         // *Índice monetário para correção do valor de aluguel.
-	TipoFianca                *string                              `json:"TipoFianca,omitempty"`                // *Tipo de seguro fiança.
-	TaxaAdm                   *float64                             `json:"TaxaAdm,omitempty"`                   // Taxa de administração do imóvel em forma de um percentual sobre o aluguel. Se for um valor fixo em Reais então informá-lo no campo 'ValorTaxaAdm' mas apenas um deles deve ser informado.
-	ValorTaxaAdm              *float64                             `json:"ValorTaxaAdm,omitempty"`              // Taxa de administração do imóvel em forma de um valor fixo em Reais. Se for um percentual sobre o aluguel então informá-lo no campo 'TaxaAdm' mas apenas um deles deve ser informado.
-	TipoAssinatura            *string                              `json:"TipoAssinatura,omitempty"`            // Tipo de assinatura do contrato de locação.
-	CodFornecCobr             *int                                 `json:"CodFornecCobr,omitempty"`             // Código de fornecedor do escritório de cobrança.
-	CodFornecFianca           *int                                 `json:"CodFornecFianca,omitempty"`           // Código de fornecedor da seguradora do seguro fiança.
-	HonorariosPerc            *float64                             `json:"HonorariosPerc,omitempty"`            // Percentual de honorários.
-	HonorariosPrazoDias       *int                                 `json:"HonorariosPrazoDias,omitempty"`       // Prazo em dias de honorários.
-	Fiadores                  *[]ActionInputFiador                 `json:"Fiadores,omitempty"`                  // Lista de pessoas que são fiadores.
-	EntregaCorrespondencia    *[]ActionInputEntregaCorrespondencia `json:"EntregaCorrespondencia,omitempty"`    //
-	Locatarios                *[]ActionInputLocatario              `json:"Locatarios,omitempty"`                // Lista de pessoas que são locatários adicionais.
+	CodImovel                 *int                                 `json:"CodImovel,omitempty" validate:"required"`          // *Código do imóvel.
+	CodIntegracaoSist         *string                              `json:"CodIntegracaoSist,omitempty"`                      // Código deste contrato de locação no sistema integrado/migrado.
+	DataAssinatura            *imobdate.Date                       `json:"DataAssinatura,omitempty" validate:"required"`     // *Data de assinatura do contrato.
+	CodPessoaLocat            *int                                 `json:"CodPessoaLocat,omitempty" validate:"required"`     // *Código de pessoa do locatário principal.
+	DataVigInicial            *imobdate.Date                       `json:"DataVigInicial,omitempty" validate:"required"`     // *Data inicial da vigência do contrato.
+	DataVigFinal              *imobdate.Date                       `json:"DataVigFinal,omitempty" validate:"required"`       // *Data final da vigência do contrato.
+	DataProxReaj              *imobdate.Date                       `json:"DataProxReaj,omitempty"`                           // Data do próximo reajuste.
+	ValorAluguel              *money.Money                         `json:"ValorAluguel,omitempty" validate:"required"`       // *Valor do aluguel inicial.
+	DataVencFianca            *imobdate.Date                       `json:"DataVencFianca,omitempty" validate:"required"`     // *Data de vencimento do seguro fiança.
+	DescricaoUso              *string                              `json:"DescricaoUso,omitempty" validate:"required"`       // *Descrição de qual será a utilização do imóvel.
+	CodAgencia                *int                                 `json:"CodAgencia,omitempty" validate:"required"`         // *Código da agência/loja a qual este contrato pertence.
+	PeriodoReajAluguel        *int                                 `json:"PeriodoReajAluguel,omitempty" validate:"required"` // *Indica o número de meses em que o aluguel sofrerá reajuste, ou seja, de quantos em quantos meses ele será reajustado.
+	Prazo                     *int                                 `json:"Prazo,omitempty" validate:"required"`              // *Prazo do contrato em meses.
+	PercMulta                 *float64                             `json:"PercMulta,omitempty" validate:"required"`          // *Percentual de multa em caso de atraso de pagamento.
+	PercJuros                 *float64                             `json:"PercJuros,omitempty" validate:"required"`          // *Percentual de juros em caso de atraso de pagamento.
+	Carencia                  *enums.Flag                          `json:"Carencia,omitempty" validate:"required"`           // *Indica se o locatário possui um período de carência inicial.
+	MesesCarencia             *int                                 `json:"MesesCarencia,omitempty"`                          // Indica quantos meses de carência é dado ao locatário.
+	DiasCarencia              *int                                 `json:"DiasCarencia,omitempty"`                           // Indica quantos dias de carência é dado ao locatário. Se for em percentual então informar no campo 'PercCarencia' mas apenas um deles deve ser informado.
+	PercCarencia              *float64                             `json:"PercCarencia,omitempty"`                           // Indica o percentual de carência que é dado ao locatário dentro do mês. Se for em número de dias então informar no campo 'DiasCarencia' mas apenas um deles deve ser informado.
+	DescPontualidade          *enums.Flag                          `json:"DescPontualidade,omitempty" validate:"required"`   // *Indica se tem desconto de pontualidade quando pago antes do vencimento.
+	PercDescPontualidade      *float64                             `json:"PercDescPontualidade,omitempty"`                   // Percentual de desconto pontualidade.
+	ValorDescPontualidade     *money.Money                         `json:"ValorDescPontualidade,omitempty"`                  // Valor fixo em Reais de desconto pontualidade, caso não se utilize um percentual de desconto.
+	DiasDescPontualidade      *int                                 `json:"DiasDescPontualidade,omitempty"`                   // Número mínimo de dias de antecipação do pagamento para habilitar o desconto pontualidade.
+	FormaCalcPagto            *string                              `json:"FormaCalcPagto,omitempty" validate:"required"`     // *Indica a forma de cálculo para o pagamento ao proprietário.
+	DiaPagtoProp              *int                                 `json:"DiaPagtoProp,omitempty"`                           // *Dia do mês para o pagamento ao proprietário quando a forma de cálculo for 'Programado'.
+	DiaVenctoDOC              *int                                 `json:"DiaVenctoDOC,omitempty" validate:"required"`       // *Dia do mês para vencimento do DOC de aluguel.
+	IsentaIR                  *enums.Flag                          `json:"IsentaIR,omitempty" validate:"required"`           // *Indica se o aluguel é isento de imposto de renda.
+	IsentaDescontoValorBaseIR *enums.Flag                          `json:"IsentaDescontoValorBaseIR,omitempty"`              // Indica se é isento do desconto do valor base do imposto de renda.
+	DocPorPeriodo             *enums.Flag                          `json:"DocPorPeriodo,omitempty" validate:"required"`      // *Indica se o aluguel é por período determinado.
+	LocacaoTemporada          *enums.Flag                          `json:"Locacao_Temporada,omitempty" validate:"required"`  // *Indica se o aluguel é por temporada.
+	IsentaTaxaPorte           *enums.Flag                          `json:"IsentaTaxaPorte,omitempty" validate:"required"`    // *Indica se deve isentar da taxa porte.
+	IsentaTarifaDOC           *enums.Flag                          `json:"IsentaTarifaDOC,omitempty" validate:"required"`    // *Indica se deve isentar da tarifa DOC.
+	DOCEmail                  *enums.Flag                          `json:"DOC_Email,omitempty" validate:"required"`          // *Indica se o DOC deve ser enviado por E-mail.
+	GaranteDOC                *enums.Flag                          `json:"GaranteDOC,omitempty" validate:"required"`         // *Indica se utiliza a modalidade de DOC garantido.
+	ValorTarifaDOC            *money.Money                         `json:"ValorTarifaDOC,omitempty"`                         // *Valor da tarifa DOC, caso não seja o valor default do sistema.
+	PercReajAluguel           *float64                             `json:"PercReajAluguel,omitempty"`                        // *Percentual para correção do valor de aluguel, caso não se utilize um índice de reajuste.
+	ValorTxIntermed           *money.Money                         `json:"ValorTxIntermed,omitempty" validate:"required"`    // *Valor da taxa de intermediação.
+	CompetIniIntermed         *imobdate.Competencia                `json:"CompetIniIntermed,omitempty" validate:"required"`  // *Competencia da cobrança inicial da taxa de intermediação no formato 'YYYYMM'.
+	NrParcIntermed            *int                                 `json:"NrParcIntermed,omitempty" validate:"required"`     // *Número de parcelas para pagamento da intermediação.
+	TipoAditamento            *string                              `json:"TipoAditamento,omitempty"`                         // Tipo do aditamento.
+	IndiceReajAluguel         *string                              `json:"IndiceReajAluguel,omitempty" validate:"required"`  // *Índice monetário para correção do valor de aluguel.
+	TipoFianca                *string                              `json:"TipoFianca,omitempty" validate:"required"`         // *Tipo de seguro fiança.
+	TaxaAdm                   *float64                             `json:"TaxaAdm,omitempty"`                                // Taxa de administração do imóvel em forma de um percentual sobre o aluguel. Se for um valor fixo em Reais então informá-lo no campo 'ValorTaxaAdm' mas apenas um deles deve ser informado.
+	ValorTaxaAdm              *money.Money                         `json:"ValorTaxaAdm,omitempty"`                           // Taxa de administração do imóvel em forma de um valor fixo em Reais. Se for um percentual sobre o aluguel então informá-lo no campo 'TaxaAdm' mas apenas um deles deve ser informado.
+	TipoAssinatura            *string                              `json:"TipoAssinatura,omitempty"`                         // Tipo de assinatura do contrato de locação.
+	CodFornecCobr             *int                                 `json:"CodFornecCobr,omitempty"`                          // Código de fornecedor do escritório de cobrança.
+	CodFornecFianca           *int                                 `json:"CodFornecFianca,omitempty"`                        // Código de fornecedor da seguradora do seguro fiança.
+	HonorariosPerc            *float64                             `json:"HonorariosPerc,omitempty"`                         // Percentual de honorários.
+	HonorariosPrazoDias       *int                                 `json:"HonorariosPrazoDias,omitempty"`                    // Prazo em dias de honorários.
+	Fiadores                  *[]ActionInputFiador                 `json:"Fiadores,omitempty"`                               // Lista de pessoas que são fiadores.
+	EntregaCorrespondencia    *[]ActionInputEntregaCorrespondencia `json:"EntregaCorrespondencia,omitempty"`                 //
+	Locatarios                *[]ActionInputLocatario              `json:"Locatarios,omitempty"`                             // Lista de pessoas que são locatários adicionais.
 }
 
 type ActionInputFiador struct {
-	CodPessoa *int `json:"CodPessoa,omitempty"` // *Código de pessoa.
+	CodPessoa *int `json:"CodPessoa,omitempty" validate:"required"` // *Código de pessoa.
 }
 
 type ActionInputEntregaCorrespondencia struct {
-	CodEntregador *string `json:"CodEntregador,omitempty"` // *Código do entregador.
-	CodServico    *int    `json:"CodServico,omitempty"`    // *Código do serviço.
-	Permanecer    *string `json:"Permanecer,omitempty"`    // *Indica se o entregador deve permanecer.
+	CodEntregador *string     `json:"CodEntregador,omitempty" validate:"required"` // *Código do entregador.
+	CodServico    *int        `json:"CodServico,omitempty" validate:"required"`    // *Código do serviço.
+	Permanecer    *enums.Flag `json:"Permanecer,omitempty" validate:"required"`    // *Indica se o entregador deve permanecer.
 }
 
 type ActionInputLocatario struct {
-	CodPessoa *int `json:"CodPessoa,omitempty"` // *Código de pessoa.
+	CodPessoa *int `json:"CodPessoa,omitempty" validate:"required"` // *Código de pessoa.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -192,6 +156,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -215,49 +183,28 @@ type RequestResponseBody struct {
 	Observacao     *string `json:"Observacao,omitempty"`     // Observação sobre a execução da ação.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
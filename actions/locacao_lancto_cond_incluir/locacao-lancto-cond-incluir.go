@@ -1,130 +1,95 @@
 package locacao_lancto_cond_incluir
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_LANCTO_COND_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	TestaAditivoLocacao         *string  `json:"TestaAditivoLocacao,omitempty"`         // *Testar existência de registro válido na tabela 'aditivo de locação'.
-	DataPagamento               *string  `json:"DataPagamento,omitempty"`               // Data de pagamento do lançamento (quando quitado).
-	LancarContaCorrenteLocacao  *string  `json:"LancarContaCorrenteLocacao,omitempty"`  // *Lançar na conta corrente de locação.
-	CompetenciaLocacao          *string  `json:"CompetenciaLocacao,omitempty"`          // *Competência.
-	TipoBoleto                  *string  `json:"TipoBoleto,omitempty"`                  // *Tipo de boleto para lançar.
-	CodImovel                   *int     `json:"CodImovel,omitempty"`                   // *Código do imóvel.
-	CodContratoLoc              *int     `json:"CodContratoLoc,omitempty"`              // *Código do contrato de locação deste imóvel.
-	Competencia                 *string  `json:"Competencia,omitempty"`                 // *Competência.
-	Complemento                 *string  `json:"Complemento,omitempty"`                 // Complemento.
-	CodTaxa                     *int     `json:"CodTaxa,omitempty"`                     // *Código da taxa que classifica este lançamento.
-	CobrarLocatarioProprietario *string  `json:"CobrarLocatarioProprietario,omitempty"` // *Cobrar do Locatario ou proprietario.
-	TotalParcelas               *int     `json:"TotalParcelas,omitempty"`               // *Quantidade total de parcelas.
-	NumeroParcela               *int     `json:"NumeroParcela,omitempty"`               // *Número da parcela do lançamento.
-	ValorReal                   *float64 `json:"ValorReal,omitempty"`                   // *Valor real.
-	DataVencimento              *string  `json:"DataVencimento,omitempty"`              // *Data de vencimento do lançamento.
-	DataVigInicial              *string  `json:"DataVigInicial,omitempty"`              // Data inicial da vigência do contrato.
-	CodBarras                   *string  `json:"CodBarras,omitempty"`                   // Código de barras do boleto.
-	CodPessoaPagador            *int     `json:"CodPessoaPagador,omitempty"`            // Código do pagador no cadastro de pessoas.
-	NomePagador                 *string  `json:"NomePagador,omitempty"`                 // Nome do beneficiário. (Para liquidação de títulos se este for diferente do condomínio).
-	CpfCnpjPagador              *int     `json:"CpfCnpjPagador,omitempty"`              // CPF ou CNPJ do pagador. (Para liquidação de títulos se este for diferente do condomínio).
-	TipoPessoaPagador           *string  `json:"TipoPessoaPagador,omitempty"`           // Tipo de pessoa do pagador. (Para liquidação de títulos se este for diferente do condomínio).
-	CodPessoaBenef              *int     `json:"CodPessoaBenef,omitempty"`              // Código de pessoa do beneficiário.
-	NomeBeneficiario            *string  `json:"NomeBeneficiario,omitempty"`            // Nome do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	CpfCnpjBeneficiario         *int     `json:"CpfCnpjBeneficiario,omitempty"`         // CPF ou CNPJ do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	TipoPessoaBeneficiario      *string  `json:"TipoPessoaBeneficiario,omitempty"`      // Tipo de pessoa do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
-	NoDemonstrativo             *string  `json:"NoDemonstrativo,omitempty"`             // Indica a forma de lançamento no demonstrativo.
+	TestaAditivoLocacao         *string               `json:"TestaAditivoLocacao,omitempty" validate:"required"`         // *Testar existência de registro válido na tabela 'aditivo de locação'.
+	DataPagamento               *imobdate.Date        `json:"DataPagamento,omitempty"`                                   // Data de pagamento do lançamento (quando quitado).
+	LancarContaCorrenteLocacao  *string               `json:"LancarContaCorrenteLocacao,omitempty" validate:"required"`  // *Lançar na conta corrente de locação.
+	CompetenciaLocacao          *imobdate.Competencia `json:"CompetenciaLocacao,omitempty" validate:"required"`          // *Competência.
+	TipoBoleto                  *string               `json:"TipoBoleto,omitempty" validate:"required"`                  // *Tipo de boleto para lançar.
+	CodImovel                   *int                  `json:"CodImovel,omitempty" validate:"required"`                   // *Código do imóvel.
+	CodContratoLoc              *int                  `json:"CodContratoLoc,omitempty" validate:"required"`              // *Código do contrato de locação deste imóvel.
+	Competencia                 *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"`                 // *Competência.
+	Complemento                 *string               `json:"Complemento,omitempty"`                                     // Complemento.
+	CodTaxa                     *int                  `json:"CodTaxa,omitempty" validate:"required"`                     // *Código da taxa que classifica este lançamento.
+	CobrarLocatarioProprietario *string               `json:"CobrarLocatarioProprietario,omitempty" validate:"required"` // *Cobrar do Locatario ou proprietario.
+	TotalParcelas               *int                  `json:"TotalParcelas,omitempty" validate:"required"`               // *Quantidade total de parcelas.
+	NumeroParcela               *int                  `json:"NumeroParcela,omitempty" validate:"required"`               // *Número da parcela do lançamento.
+	ValorReal                   *money.Money          `json:"ValorReal,omitempty" validate:"required"`                   // *Valor real.
+	DataVencimento              *imobdate.Date        `json:"DataVencimento,omitempty" validate:"required"`              // *Data de vencimento do lançamento.
+	DataVigInicial              *imobdate.Date        `json:"DataVigInicial,omitempty"`                                  // Data inicial da vigência do contrato.
+	CodBarras                   *string               `json:"CodBarras,omitempty"`                                       // Código de barras do boleto.
+	CodPessoaPagador            *int                  `json:"CodPessoaPagador,omitempty"`                                // Código do pagador no cadastro de pessoas.
+	NomePagador                 *string               `json:"NomePagador,omitempty"`                                     // Nome do beneficiário. (Para liquidação de títulos se este for diferente do condomínio).
+	CpfCnpjPagador              *doc.CPFCNPJ          `json:"CpfCnpjPagador,omitempty"`                                  // CPF ou CNPJ do pagador. (Para liquidação de títulos se este for diferente do condomínio).
+	TipoPessoaPagador           *enums.TipoPessoa     `json:"TipoPessoaPagador,omitempty"`                               // Tipo de pessoa do pagador. (Para liquidação de títulos se este for diferente do condomínio).
+	CodPessoaBenef              *int                  `json:"CodPessoaBenef,omitempty"`                                  // Código de pessoa do beneficiário.
+	NomeBeneficiario            *string               `json:"NomeBeneficiario,omitempty"`                                // Nome do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	CpfCnpjBeneficiario         *doc.CPFCNPJ          `json:"CpfCnpjBeneficiario,omitempty"`                             // CPF ou CNPJ do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	TipoPessoaBeneficiario      *enums.TipoPessoa     `json:"TipoPessoaBeneficiario,omitempty"`                          // Tipo de pessoa do beneficiário. (Para liquidação de títulos se este for diferente do fornecedor/favorecido).
+	NoDemonstrativo             *string               `json:"NoDemonstrativo,omitempty"`                                 // Indica a forma de lançamento no demonstrativo.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -152,6 +117,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -174,49 +143,28 @@ type RequestResponseBody struct {
 	CodContratoLoc   *int `json:"CodContratoLoc,omitempty"`   // Código do contrato de locação deste imóvel.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
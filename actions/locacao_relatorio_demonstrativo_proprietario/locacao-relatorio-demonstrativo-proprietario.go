@@ -1,108 +1,73 @@
 package locacao_relatorio_demonstrativo_proprietario
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_RELATORIO_DEMONSTRATIVO_PROPRIETARIO"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodPessoa      *int    `json:"CodPessoa,omitempty"`      // *Código de pessoa do proprietário.
-	Competencia    *string `json:"Competencia,omitempty"`    // *Competência do demonstrativo.
-	CodFilial      *int    `json:"CodFilial,omitempty"`      // Código da filial. Valor default é '001'.
-	ResponseFormat *string `json:"ResponseFormat,omitempty"` // Formato desejado da resposta.
+	CodPessoa      *int                  `json:"CodPessoa,omitempty" validate:"required"`   // *Código de pessoa do proprietário.
+	Competencia    *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"` // *Competência do demonstrativo.
+	CodFilial      *int                  `json:"CodFilial,omitempty"`                       // Código da filial. Valor default é '001'.
+	ResponseFormat *string               `json:"ResponseFormat,omitempty"`                  // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -130,6 +95,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -153,9 +122,9 @@ type RequestResponseBody struct {
 type RequestResponseBodyProprietario struct {
 	CodPessoa                           *int                                                     `json:"CodPessoa,omitempty"`                           // Código de pessoa do proprietário.
 	Nome                                *string                                                  `json:"Nome,omitempty"`                                // Nome do proprietário.
-	TipoPessoa                          *string                                                  `json:"TipoPessoa,omitempty"`                          // Tipo da pessoa.
-	CpfCnpj                             *int                                                     `json:"CpfCnpj,omitempty"`                             // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
-	Competencia                         *string                                                  `json:"Competencia,omitempty"`                         // Competência do demonstrativo.
+	TipoPessoa                          *enums.TipoPessoa                                        `json:"TipoPessoa,omitempty"`                          // Tipo da pessoa.
+	CpfCnpj                             *doc.CPFCNPJ                                             `json:"CpfCnpj,omitempty"`                             // Se for tipo de pessoa física o valor é um CPF. Se for tipo de pessoa jurídica o valor é um CNPJ. Se o tipo de pessoa não for informado então este campo é vazio.
+	Competencia                         *imobdate.Competencia                                    `json:"Competencia,omitempty"`                         // Competência do demonstrativo.
 	FilialNome                          *string                                                  `json:"FilialNome,omitempty"`                          // Nome da filial.
 	Titulo                              *string                                                  `json:"Titulo,omitempty"`                              // Título do relatório.
 	FormaPagto                          *string                                                  `json:"FormaPagto,omitempty"`                          // Forma de pagamento ao proprietário.
@@ -170,7 +139,7 @@ type RequestResponseBodyProprietario struct {
 	CodPessoaBenef                      *int                                                     `json:"CodPessoaBenef,omitempty"`                      // Código de pessoa do beneficiário.
 	NomeBenef                           *string                                                  `json:"NomeBenef,omitempty"`                           // Nome do beneficiário.
 	LancamentosProprietario             *[]RequestResponseBodyProprietarioLancamentoProprietario `json:"LancamentosProprietario,omitempty"`             // Lançamentos do proprietário.
-	ValorLiquidoLancamentosProprietario *float64                                                 `json:"ValorLiquidoLancamentosProprietario,omitempty"` // Valor liquido dos lançamentos do proprietário.
+	ValorLiquidoLancamentosProprietario *money.Money                                             `json:"ValorLiquidoLancamentosProprietario,omitempty"` // Valor liquido dos lançamentos do proprietário.
 	Imoveis                             *[]RequestResponseBodyProprietarioLancamentoProprietario `json:"Imoveis,omitempty"`                             // Informações de cada imóvel.
 	ResumoTaxas                         *[]RequestResponseBodyProprietarioResumoTaxa             `json:"ResumoTaxas,omitempty"`                         // Resumo de valores por taxa.
 	ResumoGeral                         *RequestResponseBodyProprietarioResumoGeral              `json:"ResumoGeral,omitempty"`                         // Resumo geral de valores.
@@ -178,12 +147,12 @@ type RequestResponseBodyProprietario struct {
 }
 
 type RequestResponseBodyProprietarioLancamentoProprietario struct {
-	Data         *string  `json:"Data,omitempty"`         // Data do lançamento.
-	Competencia  *string  `json:"Competencia,omitempty"`  // Competência do demonstrativo.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
-	NumeroLancto *int     `json:"NumeroLancto,omitempty"` // Número do lançamento.
+	Data         *imobdate.Date        `json:"Data,omitempty"`         // Data do lançamento.
+	Competencia  *imobdate.Competencia `json:"Competencia,omitempty"`  // Competência do demonstrativo.
+	Historico    *string               `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money          `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money          `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	NumeroLancto *int                  `json:"NumeroLancto,omitempty"` // Número do lançamento.
 }
 
 type RequestResponseBodyProprietarioImovel struct {
@@ -192,98 +161,77 @@ type RequestResponseBodyProprietarioImovel struct {
 	Observacao                    *string                                                  `json:"Observacao,omitempty"`                    // Observações referente ao imóvel.
 	Locatarios                    *[]RequestResponseBodyProprietarioImovelLocatario        `json:"Locatarios,omitempty"`                    // Locatários do imóvel.
 	LancamentosImovel             *[]RequestResponseBodyProprietarioImovelLancamentoImovel `json:"LancamentosImovel,omitempty"`             // Lançamentos do imóvel.
-	ValorLiquidoLancamentosImovel *float64                                                 `json:"ValorLiquidoLancamentosImovel,omitempty"` // Valor liquido dos lançamentos do imóvel.
+	ValorLiquidoLancamentosImovel *money.Money                                             `json:"ValorLiquidoLancamentosImovel,omitempty"` // Valor liquido dos lançamentos do imóvel.
 }
 
 type RequestResponseBodyProprietarioImovelLocatario struct {
-	CodPessoaLocat   *int    `json:"CodPessoaLocat,omitempty"`   // Código de pessoa do locatário principal.
-	NomeLocat        *string `json:"NomeLocat,omitempty"`        // Nome do locatário.
-	CpfCnpjLocat     *int    `json:"CpfCnpjLocat,omitempty"`     // CPF ou CNPJ do locatário.
-	TipoPessoaLocat  *string `json:"TipoPessoaLocat,omitempty"`  // Tipo da pessoa.
-	DataDesocupacao  *string `json:"DataDesocupacao,omitempty"`  // Data de desocupação.
-	DataProxReajuste *string `json:"DataProxReajuste,omitempty"` // Data do próximo reajuste.
-	IndiceReajuste   *string `json:"IndiceReajuste,omitempty"`   // Indice do reajuste.
-	DataVigInicial   *string `json:"DataVigInicial,omitempty"`   // Data inicial da vigência do contrato.
-	GaranteAluguel   *string `json:"GaranteAluguel,omitempty"`   // Indica se tem garantia de aluguel.
-	GaranteEncargos  *string `json:"GaranteEncargos,omitempty"`  // Indica se tem garantia de encargos
+	CodPessoaLocat   *int              `json:"CodPessoaLocat,omitempty"`   // Código de pessoa do locatário principal.
+	NomeLocat        *string           `json:"NomeLocat,omitempty"`        // Nome do locatário.
+	CpfCnpjLocat     *doc.CPFCNPJ      `json:"CpfCnpjLocat,omitempty"`     // CPF ou CNPJ do locatário.
+	TipoPessoaLocat  *enums.TipoPessoa `json:"TipoPessoaLocat,omitempty"`  // Tipo da pessoa.
+	DataDesocupacao  *imobdate.Date    `json:"DataDesocupacao,omitempty"`  // Data de desocupação.
+	DataProxReajuste *imobdate.Date    `json:"DataProxReajuste,omitempty"` // Data do próximo reajuste.
+	IndiceReajuste   *string           `json:"IndiceReajuste,omitempty"`   // Indice do reajuste.
+	DataVigInicial   *imobdate.Date    `json:"DataVigInicial,omitempty"`   // Data inicial da vigência do contrato.
+	GaranteAluguel   *enums.Flag       `json:"GaranteAluguel,omitempty"`   // Indica se tem garantia de aluguel.
+	GaranteEncargos  *enums.Flag       `json:"GaranteEncargos,omitempty"`  // Indica se tem garantia de encargos
 }
 
 type RequestResponseBodyProprietarioImovelLancamentoImovel struct {
-	Data         *string  `json:"Data,omitempty"`         // Data do lançamento.
-	Competencia  *string  `json:"Competencia,omitempty"`  // Competência do demonstrativo.
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
-	NumeroLancto *int     `json:"NumeroLancto,omitempty"` // Número do lançamento.
+	Data         *imobdate.Date        `json:"Data,omitempty"`         // Data do lançamento.
+	Competencia  *imobdate.Competencia `json:"Competencia,omitempty"`  // Competência do demonstrativo.
+	Historico    *string               `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money          `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money          `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	NumeroLancto *int                  `json:"NumeroLancto,omitempty"` // Número do lançamento.
 }
 
 type RequestResponseBodyProprietarioResumoTaxa struct {
-	Historico    *string  `json:"Historico,omitempty"`    // Histórico do lançamento.
-	ValorDebito  *float64 `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
-	ValorCredito *float64 `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
+	Historico    *string      `json:"Historico,omitempty"`    // Histórico do lançamento.
+	ValorDebito  *money.Money `json:"ValorDebito,omitempty"`  // Valor de débito do lançamento.
+	ValorCredito *money.Money `json:"ValorCredito,omitempty"` // Valor de crébito do lançamento.
 }
 
 type RequestResponseBodyProprietarioResumoGeral struct {
-	TotalTaxasCredito    *float64 `json:"TotalTaxasCredito,omitempty"`    // Total das taxas de crédito.
-	TotalTaxasDebito     *float64 `json:"TotalTaxasDebito,omitempty"`     // Total das taxas de débito.
-	TotalTaxasLiquido    *float64 `json:"TotalTaxasLiquido,omitempty"`    // Total líquido das taxas.
-	SaldoAnteriorCredito *float64 `json:"SaldoAnteriorCredito,omitempty"` // Valor de crédito no saldo anterior.
-	SaldoAnteriorDebito  *float64 `json:"SaldoAnteriorDebito,omitempty"`  // Valor de débito no saldo anterior.
-	SaldoAnteriorLiquido *float64 `json:"SaldoAnteriorLiquido,omitempty"` // Valor líquido do saldo anterior.
-	SaldoFinal           *float64 `json:"SaldoFinal,omitempty"`           // Saldo final líquido.
-	ValorImpostos        *float64 `json:"ValorImpostos,omitempty"`        // Valor dos impostos.
+	TotalTaxasCredito    *money.Money `json:"TotalTaxasCredito,omitempty"`    // Total das taxas de crédito.
+	TotalTaxasDebito     *money.Money `json:"TotalTaxasDebito,omitempty"`     // Total das taxas de débito.
+	TotalTaxasLiquido    *money.Money `json:"TotalTaxasLiquido,omitempty"`    // Total líquido das taxas.
+	SaldoAnteriorCredito *money.Money `json:"SaldoAnteriorCredito,omitempty"` // Valor de crédito no saldo anterior.
+	SaldoAnteriorDebito  *money.Money `json:"SaldoAnteriorDebito,omitempty"`  // Valor de débito no saldo anterior.
+	SaldoAnteriorLiquido *money.Money `json:"SaldoAnteriorLiquido,omitempty"` // Valor líquido do saldo anterior.
+	SaldoFinal           *money.Money `json:"SaldoFinal,omitempty"`           // Saldo final líquido.
+	ValorImpostos        *money.Money `json:"ValorImpostos,omitempty"`        // Valor dos impostos.
 }
 
 type RequestResponseBodyProprietarioPagamento struct {
-	Data     *string  `json:"Data,omitempty"`     // Data do lançamento.
-	Valor    *float64 `json:"Valor,omitempty"`    // Valor pago ao proprietário.
-	Situacao *string  `json:"Situacao,omitempty"` // Situação do pagamento.
-	Saldo    *float64 `json:"Saldo,omitempty"`    // Saldo do proprietário.
+	Data     *imobdate.Date `json:"Data,omitempty"`     // Data do lançamento.
+	Valor    *money.Money   `json:"Valor,omitempty"`    // Valor pago ao proprietário.
+	Situacao *string        `json:"Situacao,omitempty"` // Situação do pagamento.
+	Saldo    *money.Money   `json:"Saldo,omitempty"`    // Saldo do proprietário.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,113 +1,77 @@
 package locacao_relatorio_mensal
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_RELATORIO_MENSAL"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	Competencia       *string `json:"Competencia,omitempty"`       // *Competência do relatório mensal a gerar.
-	CodFilial         *int    `json:"CodFilial,omitempty"`         // Código da filial a gerar. Valor default é '000'.
-	DadosBoletos      *string `json:"DadosBoletos,omitempty"`      // Indica para gerar dados totalizados dos boletos. Valor default é 'S'.
-	TaxaBoletos       *string `json:"TaxaBoletos,omitempty"`       // Indica para gerar relação de taxas dos boletos. Valor default é 'N'.
-	TaxaAdministracao *string `json:"TaxaAdministracao,omitempty"` // Indica para gerar relação de taxas de administração. Valor default é 'N'.
-	PagtoProprietario *string `json:"PagtoProprietario,omitempty"` // Indica para gerar relação de pagamento de proprietário. Valor default é 'N'.
-	NumerosLocacao    *string `json:"NumerosLocacao,omitempty"`    // Indica para gerar relação de números da locação. Valor default é 'N'.
-	BoletosBancos     *string `json:"BoletosBancos,omitempty"`     // Indica para gerar informações sintéticas dos boletos por banco. Valor default é 'N'.
-	ResponseFormat    *string `json:"ResponseFormat,omitempty"`    // Formato desejado da resposta.
+	Competencia       *imobdate.Competencia `json:"Competencia,omitempty" validate:"required"` // *Competência do relatório mensal a gerar.
+	CodFilial         *int                  `json:"CodFilial,omitempty"`                       // Código da filial a gerar. Valor default é '000'.
+	DadosBoletos      *enums.Flag           `json:"DadosBoletos,omitempty"`                    // Indica para gerar dados totalizados dos boletos. Valor default é 'S'.
+	TaxaBoletos       *enums.Flag           `json:"TaxaBoletos,omitempty"`                     // Indica para gerar relação de taxas dos boletos. Valor default é 'N'.
+	TaxaAdministracao *enums.Flag           `json:"TaxaAdministracao,omitempty"`               // Indica para gerar relação de taxas de administração. Valor default é 'N'.
+	PagtoProprietario *enums.Flag           `json:"PagtoProprietario,omitempty"`               // Indica para gerar relação de pagamento de proprietário. Valor default é 'N'.
+	NumerosLocacao    *enums.Flag           `json:"NumerosLocacao,omitempty"`                  // Indica para gerar relação de números da locação. Valor default é 'N'.
+	BoletosBancos     *enums.Flag           `json:"BoletosBancos,omitempty"`                   // Indica para gerar informações sintéticas dos boletos por banco. Valor default é 'N'.
+	ResponseFormat    *string               `json:"ResponseFormat,omitempty"`                  // Formato desejado da resposta.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -135,6 +99,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -153,7 +121,7 @@ type RequestResponseHeader struct {
 
 type RequestResponseBody struct {
 	TiposImoveis             *[]RequestResponseBodyTipoImovel             //
-	Competencia              *string                                      // Competência do relatório mensal a gerar.
+	Competencia              *imobdate.Competencia                        // Competência do relatório mensal a gerar.
 	TaxasBoletos             *[]RequestResponseBodyTaxaBoleto             //
 	TotaisTaxas              *RequestResponseBodyTotaisTaxas              //
 	DadosBoletos             *RequestResponseBodyDadosBoletos             // Indica para gerar dados totalizados dos boletos.
@@ -173,76 +141,76 @@ type RequestResponseBodyTipoImovel struct {
 }
 
 type RequestResponseBodyTipoImovelImovel struct {
-	Classe    *string  `json:"Classe,omitempty"`    // Classe do imóvel.
-	QtdClasse *int     `json:"QtdClasse,omitempty"` // Quantidade de imóveis desta classe.
-	VlrClasse *float64 `json:"VlrClasse,omitempty"` // Valor total de aluguéis desta classe.
+	Classe    *string      `json:"Classe,omitempty"`    // Classe do imóvel.
+	QtdClasse *int         `json:"QtdClasse,omitempty"` // Quantidade de imóveis desta classe.
+	VlrClasse *money.Money `json:"VlrClasse,omitempty"` // Valor total de aluguéis desta classe.
 }
 
 type RequestResponseBodyTaxaBoleto struct {
-	CodTaxa *int     `json:"CodTaxa,omitempty"` // Codigo da taxa.
-	Taxa    *string  `json:"Taxa,omitempty"`    // Descrição da taxa.
-	Debito  *float64 `json:"Debito,omitempty"`  // Valor de débito.
-	Credito *float64 `json:"Credito,omitempty"` // Valor de crédito.
-	Total   *float64 `json:"Total,omitempty"`   // Valor total.
+	CodTaxa *int         `json:"CodTaxa,omitempty"` // Codigo da taxa.
+	Taxa    *string      `json:"Taxa,omitempty"`    // Descrição da taxa.
+	Debito  *money.Money `json:"Debito,omitempty"`  // Valor de débito.
+	Credito *money.Money `json:"Credito,omitempty"` // Valor de crédito.
+	Total   *money.Money `json:"Total,omitempty"`   // Valor total.
 }
 
 type RequestResponseBodyTotaisTaxas struct {
-	Debito  *float64 `json:"Debito,omitempty"`  // Valor de débito.
-	Credito *float64 `json:"Credito,omitempty"` // Valor de crédito.
-	Total   *float64 `json:"Total,omitempty"`   // Valor total.
+	Debito  *money.Money `json:"Debito,omitempty"`  // Valor de débito.
+	Credito *money.Money `json:"Credito,omitempty"` // Valor de crédito.
+	Total   *money.Money `json:"Total,omitempty"`   // Valor total.
 }
 
 type RequestResponseBodyDadosBoletos struct {
-	TaxaPorte           *float64 `json:"TaxaPorte,omitempty"`           // Valor de taxa porte.
-	DescAdministradora  *float64 `json:"DescAdministradora,omitempty"`  // Valor de desconto da administradora.
-	MultaAdministradora *float64 `json:"MultaAdministradora,omitempty"` // Valor de multa da administradora.
-	JurosAdministradora *float64 `json:"JurosAdministradora,omitempty"` // Valor de juros da administradora.
-	OutrosAcrescimos    *float64 `json:"OutrosAcrescimos,omitempty"`    // Valor de outros acréscimos.
-	TarifaDoc           *float64 `json:"TarifaDoc,omitempty"`           // Valor de emissão do boleto (tarifa DOC).
-	DescProprietario    *float64 `json:"DescProprietario,omitempty"`    // Valor de desconto do proprietário.
-	MultaProprietario   *float64 `json:"MultaProprietario,omitempty"`   // Valor de multa do proprietário.
-	JurosProprietario   *float64 `json:"JurosProprietario,omitempty"`   // Valor de juros do proprietário.
+	TaxaPorte           *money.Money `json:"TaxaPorte,omitempty"`           // Valor de taxa porte.
+	DescAdministradora  *money.Money `json:"DescAdministradora,omitempty"`  // Valor de desconto da administradora.
+	MultaAdministradora *money.Money `json:"MultaAdministradora,omitempty"` // Valor de multa da administradora.
+	JurosAdministradora *money.Money `json:"JurosAdministradora,omitempty"` // Valor de juros da administradora.
+	OutrosAcrescimos    *money.Money `json:"OutrosAcrescimos,omitempty"`    // Valor de outros acréscimos.
+	TarifaDoc           *money.Money `json:"TarifaDoc,omitempty"`           // Valor de emissão do boleto (tarifa DOC).
+	DescProprietario    *money.Money `json:"DescProprietario,omitempty"`    // Valor de desconto do proprietário.
+	MultaProprietario   *money.Money `json:"MultaProprietario,omitempty"`   // Valor de multa do proprietário.
+	JurosProprietario   *money.Money `json:"JurosProprietario,omitempty"`   // Valor de juros do proprietário.
 }
 
 type RequestResponseBodyQuadroBoletos struct {
-	VlrBoletosEmitidos            *float64 `json:"VlrBoletosEmitidos,omitempty"`            // Valor total de boletos emitidos.
-	QtdBoletosEmitidos            *int     `json:"QtdBoletosEmitidos,omitempty"`            // Quantidade de boletos emitidos.
-	VlrBoletosPagosMesAtual       *float64 `json:"VlrBoletosPagosMesAtual,omitempty"`       // Valor total de boletos pagos do mês.
-	QtdBoletosPagosMesAtual       *int     `json:"QtdBoletosPagosMesAtual,omitempty"`       // Quantidade de boletos pagos do mês.
-	VlrBoletosPagosMesAnt         *float64 `json:"VlrBoletosPagosMesAnt,omitempty"`         // Valor total de boletos pagos meses anteriores.
-	QtdBoletosPagosMesAnt         *int     `json:"QtdBoletosPagosMesAnt,omitempty"`         // Quantidade de boletos pagos meses anteriores.
-	VlrBoletosPagosMesFuturo      *float64 `json:"VlrBoletosPagosMesFuturo,omitempty"`      // Valor total de boletos pagos meses futuros.
-	QtdBoletosPagosMesFuturo      *int     `json:"QtdBoletosPagosMesFuturo,omitempty"`      // Quantidade de boletos pagos meses futuros.
-	VlrBoletosNaoPagosCompetAtual *float64 `json:"VlrBoletosNaoPagosCompetAtual,omitempty"` // Valor total de boletos não quitados na competência.
-	QtdBoletosNaoPagosCompetAtual *int     `json:"QtdBoletosNaoPagosCompetAtual,omitempty"` // Quantidade de boletos não quitados na competência.
-	VlrBoletosNaoPagosCompetAnt   *float64 `json:"VlrBoletosNaoPagosCompetAnt,omitempty"`   // Valor total de boletos não quitados em competências anteriores.
-	QtdBoletosNaoPagosCompetAnt   *int     `json:"QtdBoletosNaoPagosCompetAnt,omitempty"`   // Quantidade de boletos não quitados em competências anteriores.
+	VlrBoletosEmitidos            *money.Money `json:"VlrBoletosEmitidos,omitempty"`            // Valor total de boletos emitidos.
+	QtdBoletosEmitidos            *int         `json:"QtdBoletosEmitidos,omitempty"`            // Quantidade de boletos emitidos.
+	VlrBoletosPagosMesAtual       *money.Money `json:"VlrBoletosPagosMesAtual,omitempty"`       // Valor total de boletos pagos do mês.
+	QtdBoletosPagosMesAtual       *int         `json:"QtdBoletosPagosMesAtual,omitempty"`       // Quantidade de boletos pagos do mês.
+	VlrBoletosPagosMesAnt         *money.Money `json:"VlrBoletosPagosMesAnt,omitempty"`         // Valor total de boletos pagos meses anteriores.
+	QtdBoletosPagosMesAnt         *int         `json:"QtdBoletosPagosMesAnt,omitempty"`         // Quantidade de boletos pagos meses anteriores.
+	VlrBoletosPagosMesFuturo      *money.Money `json:"VlrBoletosPagosMesFuturo,omitempty"`      // Valor total de boletos pagos meses futuros.
+	QtdBoletosPagosMesFuturo      *int         `json:"QtdBoletosPagosMesFuturo,omitempty"`      // Quantidade de boletos pagos meses futuros.
+	VlrBoletosNaoPagosCompetAtual *money.Money `json:"VlrBoletosNaoPagosCompetAtual,omitempty"` // Valor total de boletos não quitados na competência.
+	QtdBoletosNaoPagosCompetAtual *int         `json:"QtdBoletosNaoPagosCompetAtual,omitempty"` // Quantidade de boletos não quitados na competência.
+	VlrBoletosNaoPagosCompetAnt   *money.Money `json:"VlrBoletosNaoPagosCompetAnt,omitempty"`   // Valor total de boletos não quitados em competências anteriores.
+	QtdBoletosNaoPagosCompetAnt   *int         `json:"QtdBoletosNaoPagosCompetAnt,omitempty"`   // Quantidade de boletos não quitados em competências anteriores.
 }
 
 type RequestResponseBodyInadimplencias struct {
-	VlrInadimplencias *float64 `json:"VlrInadimplencias,omitempty"` // Valor total de boletos de aluguéis atrasados.
-	QtdInadimplencias *int     `json:"QtdInadimplencias,omitempty"` // Quantidade de boletos de aluguéis atrasados.
+	VlrInadimplencias *money.Money `json:"VlrInadimplencias,omitempty"` // Valor total de boletos de aluguéis atrasados.
+	QtdInadimplencias *int         `json:"QtdInadimplencias,omitempty"` // Quantidade de boletos de aluguéis atrasados.
 }
 
 type RequestResponseBodyTaxasAdministracao struct {
-	VlrPrevisaoBoletosMes     *float64 `json:"VlrPrevisaoBoletosMes,omitempty"`     // Valor da previsão de taxa de administração dos boletos gerados do mês.
-	VlrEfetivoBoletosMes      *float64 `json:"VlrEfetivoBoletosMes,omitempty"`      // Valor total da taxa de administração efetiva dos boletos do mês.
-	VlrTotalRecebidoMes       *float64 `json:"VlrTotalRecebidoMes,omitempty"`       // Valor total da taxa de administração recebida no mês.
-	VlrRecebidoMesSemGarantia *float64 `json:"VlrRecebidoMesSemGarantia,omitempty"` // Valor da taxa de administração recebida no mês - sem garantia.
-	VlrGarantidoMes           *float64 `json:"VlrGarantidoMes,omitempty"`           // Valor da taxa de administração garantida no mês.
-	VlrGarantidoRecebidoMes   *float64 `json:"VlrGarantidoRecebidoMes,omitempty"`   // Valor da taxa de administração garantida e recebida no mesmo mês.
-	VlrGarantidoOutroMes      *float64 `json:"VlrGarantidoOutroMes,omitempty"`      // Valor da taxa de administração de doc garantido em outro mês e quitado neste.
-	VlrDemonstrativoProp      *float64 `json:"VlrDemonstrativoProp,omitempty"`      // Valor da taxa de admnistração dentro dos demonstrativos de proprietários.
-	VlrIntermediacaoMes       *float64 `json:"VlrIntermediacaoMes,omitempty"`       // Valor total da taxa de intermediação efetiva no mês.
+	VlrPrevisaoBoletosMes     *money.Money `json:"VlrPrevisaoBoletosMes,omitempty"`     // Valor da previsão de taxa de administração dos boletos gerados do mês.
+	VlrEfetivoBoletosMes      *money.Money `json:"VlrEfetivoBoletosMes,omitempty"`      // Valor total da taxa de administração efetiva dos boletos do mês.
+	VlrTotalRecebidoMes       *money.Money `json:"VlrTotalRecebidoMes,omitempty"`       // Valor total da taxa de administração recebida no mês.
+	VlrRecebidoMesSemGarantia *money.Money `json:"VlrRecebidoMesSemGarantia,omitempty"` // Valor da taxa de administração recebida no mês - sem garantia.
+	VlrGarantidoMes           *money.Money `json:"VlrGarantidoMes,omitempty"`           // Valor da taxa de administração garantida no mês.
+	VlrGarantidoRecebidoMes   *money.Money `json:"VlrGarantidoRecebidoMes,omitempty"`   // Valor da taxa de administração garantida e recebida no mesmo mês.
+	VlrGarantidoOutroMes      *money.Money `json:"VlrGarantidoOutroMes,omitempty"`      // Valor da taxa de administração de doc garantido em outro mês e quitado neste.
+	VlrDemonstrativoProp      *money.Money `json:"VlrDemonstrativoProp,omitempty"`      // Valor da taxa de admnistração dentro dos demonstrativos de proprietários.
+	VlrIntermediacaoMes       *money.Money `json:"VlrIntermediacaoMes,omitempty"`       // Valor total da taxa de intermediação efetiva no mês.
 }
 
 type RequestResponseBodyQuadroPagtoProprietarios struct {
-	VlrGerado        *float64 `json:"VlrGerado,omitempty"`        // Valor total de gerações no mês.
-	QtdGerado        *int     `json:"QtdGerado,omitempty"`        // Quantidade de gerações no mês.
-	VlrPago          *float64 `json:"VlrPago,omitempty"`          // Valor total de gerações pagas.
-	QtdPago          *int     `json:"QtdPago,omitempty"`          // Quantidade de gerações pagas.
-	VlrTarifaRemessa *float64 `json:"VlrTarifaRemessa,omitempty"` // Valor total de tarifa remessa.
-	QtdTarifaRemessa *int     `json:"QtdTarifaRemessa,omitempty"` // Quantidade de tarifa remessa.
+	VlrGerado        *money.Money `json:"VlrGerado,omitempty"`        // Valor total de gerações no mês.
+	QtdGerado        *int         `json:"QtdGerado,omitempty"`        // Quantidade de gerações no mês.
+	VlrPago          *money.Money `json:"VlrPago,omitempty"`          // Valor total de gerações pagas.
+	QtdPago          *int         `json:"QtdPago,omitempty"`          // Quantidade de gerações pagas.
+	VlrTarifaRemessa *money.Money `json:"VlrTarifaRemessa,omitempty"` // Valor total de tarifa remessa.
+	QtdTarifaRemessa *int         `json:"QtdTarifaRemessa,omitempty"` // Quantidade de tarifa remessa.
 }
 
 type RequestResponseBodyNumerosLocacao struct {
@@ -267,12 +235,12 @@ type RequestResponseBodySituacaoImoveis struct {
 }
 
 type RequestResponseBodyTotaisLocacao struct {
-	QtdTotalImoveis         *int     `json:"QtdTotalImoveis,omitempty"`         // Quantidade total de imóveis.
-	VlrAlugueisResidenciais *float64 `json:"VlrAlugueisResidenciais,omitempty"` // Valor total de aluguel para imóveis residenciais.
-	QtdAlugueisResidenciais *int     `json:"QtdAlugueisResidenciais,omitempty"` // Quantidade de imóveis com aluguel residencial.
-	VlrAlugueisComerciais   *float64 `json:"VlrAlugueisComerciais,omitempty"`   // Valor total de aluguel para imóveis comerciais.
-	QtdAlugueisComerciais   *int     `json:"QtdAlugueisComerciais,omitempty"`   // Quantidade de imóveis com aluguel comercial.
-	VlrAlugueis             *float64 `json:"VlrAlugueis,omitempty"`             // Valor total de aluguéis.
+	QtdTotalImoveis         *int         `json:"QtdTotalImoveis,omitempty"`         // Quantidade total de imóveis.
+	VlrAlugueisResidenciais *money.Money `json:"VlrAlugueisResidenciais,omitempty"` // Valor total de aluguel para imóveis residenciais.
+	QtdAlugueisResidenciais *int         `json:"QtdAlugueisResidenciais,omitempty"` // Quantidade de imóveis com aluguel residencial.
+	VlrAlugueisComerciais   *money.Money `json:"VlrAlugueisComerciais,omitempty"`   // Valor total de aluguel para imóveis comerciais.
+	QtdAlugueisComerciais   *int         `json:"QtdAlugueisComerciais,omitempty"`   // Quantidade de imóveis com aluguel comercial.
+	VlrAlugueis             *money.Money `json:"VlrAlugueis,omitempty"`             // Valor total de aluguéis.
 }
 
 type RequestResponseBodyTipoBoletos struct {
@@ -288,67 +256,46 @@ type RequestResponseBodyTipoBoletosBanco struct {
 }
 
 type RequestResponseBodyTipoBoletosBancoBoleto struct {
-	Data      *string  `json:"Data,omitempty"`      // Data.
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos.
+	Data      *imobdate.Date `json:"Data,omitempty"`      // Data.
+	QtdTotal  *int           `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos.
+	VlrTotal  *money.Money   `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos.
+	QtdNormal *int           `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras.
+	VlrNormal *money.Money   `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras.
+	QtdRetido *int           `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos.
+	VlrRetido *money.Money   `json:"VlrRetido,omitempty"` // Valor dos boletos retidos.
 }
 
 type RequestResponseBodyTipoBoletosBancoTotaisBanco struct {
-	QtdTotal  *int     `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos.
-	VlrTotal  *float64 `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos.
-	QtdNormal *int     `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras.
-	VlrNormal *float64 `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras.
-	QtdRetido *int     `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos.
-	VlrRetido *float64 `json:"VlrRetido,omitempty"` // Valor dos boletos retidos.
+	QtdTotal  *int         `json:"QtdTotal,omitempty"`  // Quantidade de boletos emitidos.
+	VlrTotal  *money.Money `json:"VlrTotal,omitempty"`  // Valor total de boletos emitidos.
+	QtdNormal *int         `json:"QtdNormal,omitempty"` // Quantidade de boletos normais/extras.
+	VlrNormal *money.Money `json:"VlrNormal,omitempty"` // Valor dos boletos normais/extras.
+	QtdRetido *int         `json:"QtdRetido,omitempty"` // Quantidades de boletos rettidos.
+	VlrRetido *money.Money `json:"VlrRetido,omitempty"` // Valor dos boletos retidos.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
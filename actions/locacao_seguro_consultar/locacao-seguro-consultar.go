@@ -1,106 +1,70 @@
 package locacao_seguro_consultar
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/enums"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_SEGURO_CONSULTAR"
 
+var IDEMPOTENT = true
+
 type ActionInput struct {
-	CodImovel      *int `json:"CodImovel,omitempty"`      // *Código do imóvel.
-	CodContratoLoc *int `json:"CodContratoLoc,omitempty"` // *Código do contrato de locação deste imóvel.
+	CodImovel      *int `json:"CodImovel,omitempty" validate:"required"`      // *Código do imóvel.
+	CodContratoLoc *int `json:"CodContratoLoc,omitempty" validate:"required"` // *Código do contrato de locação deste imóvel.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -128,6 +92,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -149,73 +117,52 @@ type RequestResponseBody struct {
 }
 
 type RequestResponseBodyLista struct {
-	CompetenciaInicial *string  `json:"CompetenciaInicial,omitempty"` // Competência inicial da cobrança das parcelas.
-	CobrancaGerada     *string  `json:"CobrancaGerada,omitempty"`     // Indica se a lançamento foi gerado no boleto de cobrança para o locatário/proprietário.
-	IdSeguroImovel     *int     `json:"IdSeguroImovel,omitempty"`     // ID interno do Imobiliar (é retornado na operação de consulta).
-	TipoSeguro         *string  `json:"TipoSeguro,omitempty"`         // Tipo do seguro contratado (Fiança, Incêndio, etc.).
-	VigenciaInicial    *string  `json:"VigenciaInicial,omitempty"`    // Data inicial da vigência do seguro contratado.
-	VigenciaFinal      *string  `json:"VigenciaFinal,omitempty"`      // Data final da vigência do seguro contratado.
-	Apolice            *string  `json:"Apolice,omitempty"`            // Número da apólice.
-	Proposta           *string  `json:"Proposta,omitempty"`           // Número da proposta.
-	CodRisco           *string  `json:"CodRisco,omitempty"`           //
-	ValorPremioTotal   *float64 `json:"ValorPremioTotal,omitempty"`   // Valor total do prêmio a ser pago à seguradora.
-	ValorSegurado      *float64 `json:"ValorSegurado,omitempty"`      // Valor segurado contratado.
-	NumeroParcelas     *int     `json:"NumeroParcelas,omitempty"`     // Número de parcelas.
-	CodSeguradora      *int     `json:"CodSeguradora,omitempty"`      // Código interno da seguradora no Imibiliar.
-	CodCorretor        *int     `json:"CodCorretor,omitempty"`        // Código interno da empresa corretora do seguro no Imobiliar.
-	ContasPagarQuitado *string  `json:"ContasPagarQuitado,omitempty"` // Número de parcelas.
-	NossoNumero        *string  `json:"NossoNumero,omitempty"`        // Número de identificação bancário.
-	CompetenciaParcela *string  `json:"CompetenciaParcela,omitempty"` // Competência da parcela.
-	NumeroParcela      *int     `json:"NumeroParcela,omitempty"`      // Número da parcela.
-	ValorParcela       *float64 `json:"ValorParcela,omitempty"`       // Valor da parcela.
-	Cobrado            *string  `json:"Cobrado,omitempty"`            // Se foi cobrado no boleto.
-	NumeroLancto       *int     `json:"NumeroLancto,omitempty"`       // Id do lançamento no Contas à Pagar.
-	DataVencimento     *string  `json:"DataVencimento,omitempty"`     // Data de vencimento do lançamento.
-}
-
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+	CompetenciaInicial *imobdate.Competencia `json:"CompetenciaInicial,omitempty"` // Competência inicial da cobrança das parcelas.
+	CobrancaGerada     *enums.Flag           `json:"CobrancaGerada,omitempty"`     // Indica se a lançamento foi gerado no boleto de cobrança para o locatário/proprietário.
+	IdSeguroImovel     *int                  `json:"IdSeguroImovel,omitempty"`     // ID interno do Imobiliar (é retornado na operação de consulta).
+	TipoSeguro         *string               `json:"TipoSeguro,omitempty"`         // Tipo do seguro contratado (Fiança, Incêndio, etc.).
+	VigenciaInicial    *imobdate.Date        `json:"VigenciaInicial,omitempty"`    // Data inicial da vigência do seguro contratado.
+	VigenciaFinal      *imobdate.Date        `json:"VigenciaFinal,omitempty"`      // Data final da vigência do seguro contratado.
+	Apolice            *string               `json:"Apolice,omitempty"`            // Número da apólice.
+	Proposta           *string               `json:"Proposta,omitempty"`           // Número da proposta.
+	CodRisco           *string               `json:"CodRisco,omitempty"`           //
+	ValorPremioTotal   *money.Money          `json:"ValorPremioTotal,omitempty"`   // Valor total do prêmio a ser pago à seguradora.
+	ValorSegurado      *money.Money          `json:"ValorSegurado,omitempty"`      // Valor segurado contratado.
+	NumeroParcelas     *int                  `json:"NumeroParcelas,omitempty"`     // Número de parcelas.
+	CodSeguradora      *int                  `json:"CodSeguradora,omitempty"`      // Código interno da seguradora no Imibiliar.
+	CodCorretor        *int                  `json:"CodCorretor,omitempty"`        // Código interno da empresa corretora do seguro no Imobiliar.
+	ContasPagarQuitado *string               `json:"ContasPagarQuitado,omitempty"` // Número de parcelas.
+	NossoNumero        *string               `json:"NossoNumero,omitempty"`        // Número de identificação bancário.
+	CompetenciaParcela *imobdate.Competencia `json:"CompetenciaParcela,omitempty"` // Competência da parcela.
+	NumeroParcela      *int                  `json:"NumeroParcela,omitempty"`      // Número da parcela.
+	ValorParcela       *money.Money          `json:"ValorParcela,omitempty"`       // Valor da parcela.
+	Cobrado            *string               `json:"Cobrado,omitempty"`            // Se foi cobrado no boleto.
+	NumeroLancto       *int                  `json:"NumeroLancto,omitempty"`       // Id do lançamento no Contas à Pagar.
+	DataVencimento     *imobdate.Date        `json:"DataVencimento,omitempty"`     // Data de vencimento do lançamento.
+}
+
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
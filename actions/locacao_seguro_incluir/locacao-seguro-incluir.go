@@ -1,128 +1,91 @@
 package locacao_seguro_incluir
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
-	"sync"
+	"iter"
 
 	"github.com/itispx/goimobiliar/consts"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
 	"github.com/itispx/goimobiliar/session"
 )
 
 var ACTION = "LOCACAO_SEGURO_INCLUIR"
 
+var IDEMPOTENT = false
+
 type ActionInput struct {
-	CodImovel              *int                `json:"CodImovel,omitempty"`              // *Código do imóvel.
-	VigenciaInicial        *string             `json:"VigenciaInicial,omitempty"`        // *Data inicial da vigência do seguro contratado.
-	VigenciaFinal          *string             `json:"VigenciaFinal,omitempty"`          // *Data final da vigência do seguro contratado.
-	Apolice                *string             `json:"Apolice,omitempty"`                // Número da apólice.
-	CodRisco               *string             `json:"CodRisco,omitempty"`               //
-	Proposta               *string             `json:"Proposta,omitempty"`               // Número da proposta.
-	TipoSeguro             *string             `json:"TipoSeguro,omitempty"`             // *Tipo do seguro contratado (Fiança, Incêndio, etc.).
-	CodSeguradora          *int                `json:"CodSeguradora,omitempty"`          // *Código interno da seguradora no Imibiliar.
-	CodCorretor            *int                `json:"CodCorretor,omitempty"`            // Código interno da empresa corretora do seguro no Imobiliar.
-	ValorSegurado          *float64            `json:"ValorSegurado,omitempty"`          // *Valor segurado contratado.
-	ValorPremioTotal       *float64            `json:"ValorPremioTotal,omitempty"`       // *Valor total do prêmio a ser pago à seguradora.
-	LancarContasPagar      *string             `json:"LancarContasPagar,omitempty"`      // *Este campo indica se deve fazer a incluisão das parcelas no Contas à Pagar.
-	CompetenciaInicial     *string             `json:"CompetenciaInicial,omitempty"`     // Competência inicial da cobrança das parcelas.
-	DataVencimentoInicial  *string             `json:"DataVencimentoInicial,omitempty"`  // Data de vencimento da primeira parcela.
-	NumeroParcelaResidual  *int                `json:"NumeroParcelaResidual,omitempty"`  // Número da parcela a aplicar o valor residual (informar 1).
-	ValorParcela           *float64            `json:"ValorParcela,omitempty"`           // Valor da parcela.
-	NumeroParcelas         *int                `json:"NumeroParcelas,omitempty"`         // Número de parcelas.
-	ValorParcelasRestantes *float64            `json:"ValorParcelasRestantes,omitempty"` //
-	Lista                  *[]ActionInputLista `json:"Lista,omitempty"`                  // *Lista de parcelas de valores prédefinidos.
+	CodImovel              *int                  `json:"CodImovel,omitempty" validate:"required"`         // *Código do imóvel.
+	VigenciaInicial        *imobdate.Date        `json:"VigenciaInicial,omitempty" validate:"required"`   // *Data inicial da vigência do seguro contratado.
+	VigenciaFinal          *imobdate.Date        `json:"VigenciaFinal,omitempty" validate:"required"`     // *Data final da vigência do seguro contratado.
+	Apolice                *string               `json:"Apolice,omitempty"`                               // Número da apólice.
+	CodRisco               *string               `json:"CodRisco,omitempty"`                              //
+	Proposta               *string               `json:"Proposta,omitempty"`                              // Número da proposta.
+	TipoSeguro             *string               `json:"TipoSeguro,omitempty" validate:"required"`        // *Tipo do seguro contratado (Fiança, Incêndio, etc.).
+	CodSeguradora          *int                  `json:"CodSeguradora,omitempty" validate:"required"`     // *Código interno da seguradora no Imibiliar.
+	CodCorretor            *int                  `json:"CodCorretor,omitempty"`                           // Código interno da empresa corretora do seguro no Imobiliar.
+	ValorSegurado          *money.Money          `json:"ValorSegurado,omitempty" validate:"required"`     // *Valor segurado contratado.
+	ValorPremioTotal       *money.Money          `json:"ValorPremioTotal,omitempty" validate:"required"`  // *Valor total do prêmio a ser pago à seguradora.
+	LancarContasPagar      *string               `json:"LancarContasPagar,omitempty" validate:"required"` // *Este campo indica se deve fazer a incluisão das parcelas no Contas à Pagar.
+	CompetenciaInicial     *imobdate.Competencia `json:"CompetenciaInicial,omitempty"`                    // Competência inicial da cobrança das parcelas.
+	DataVencimentoInicial  *imobdate.Date        `json:"DataVencimentoInicial,omitempty"`                 // Data de vencimento da primeira parcela.
+	NumeroParcelaResidual  *int                  `json:"NumeroParcelaResidual,omitempty"`                 // Número da parcela a aplicar o valor residual (informar 1).
+	ValorParcela           *money.Money          `json:"ValorParcela,omitempty"`                          // Valor da parcela.
+	NumeroParcelas         *int                  `json:"NumeroParcelas,omitempty"`                        // Número de parcelas.
+	ValorParcelasRestantes *money.Money          `json:"ValorParcelasRestantes,omitempty"`                //
+	Lista                  *[]ActionInputLista   `json:"Lista,omitempty" validate:"required"`             // *Lista de parcelas de valores prédefinidos.
 }
 
 type ActionInputLista struct {
-	NumeroParcela *int `json:"NumeroParcela,omitempty"` // *Número da parcela.
-	ValorParcela  *int `json:"ValorParcela,omitempty"`  // *Valor da parcela.
+	NumeroParcela *int         `json:"NumeroParcela,omitempty" validate:"required"` // *Número da parcela.
+	ValorParcela  *money.Money `json:"ValorParcela,omitempty" validate:"required"`  // *Valor da parcela.
 }
 
 type RunMultiInput consts.RunMultiInput[*ActionInput]
 type RunMultiOutput consts.RunMultiOutput[*RunOutput]
 
 func RunMulti(input *RunMultiInput) (*RunMultiOutput, error) {
-	output := make(RunMultiOutput, 0, len(input.Entries))
-
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-
-	for _, entry := range input.Entries {
-		if input.Parallel {
-			wg.Add(1)
-			go func(entry *consts.RunMultiInputEntry[*ActionInput]) {
-				defer wg.Done()
-				mu.Lock()
-				output = append(output, runMultiHandler(entry))
-				mu.Unlock()
-			}(entry)
-		} else {
-			output = append(output, runMultiHandler(entry))
-		}
-	}
-
-	if input.Parallel {
-		wg.Wait()
-	}
-
-	return &output, nil
+	return RunMultiContext(context.Background(), input)
 }
 
-func runMultiHandler(input *consts.RunMultiInputEntry[*ActionInput]) *consts.RunMultiOutputEntry[*RunOutput] {
-	outputEntry := consts.RunMultiOutputEntry[*RunOutput]{
-		ImobId: input.ImobId,
-	}
-
-	sess, err := session.NewSession(&session.NewInput{
-		Endpoint: input.Endpoint,
-		ImobId:   input.ImobId,
-		UserId:   input.UserId,
-		UserPass: input.UserPass,
-	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
+func RunMultiContext(ctx context.Context, input *RunMultiInput) (*RunMultiOutput, error) {
+	output, err := consts.RunMultiContext(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
 
-		return &outputEntry
-	}
+	return (*RunMultiOutput)(&output), err
+}
 
-	defer sess.EndSession()
+func RunMultiStream(ctx context.Context, input *RunMultiInput) iter.Seq2[int, *consts.RunMultiOutputEntry[*RunOutput]] {
+	return consts.RunMultiStream(ctx, (*consts.RunMultiInput[*ActionInput])(input), runMultiHandler)
+}
 
-	handlerOutput, err := Run(&RunInput{
+func runMultiHandler(ctx context.Context, sess *session.Session, input *ActionInput) (*RunOutput, error) {
+	return RunContext(ctx, &RunInput{
 		Session:     sess,
-		ActionInput: input.Input,
+		ActionInput: input,
 	})
-	if err != nil {
-		msg := err.Error()
-
-		outputEntry.Success = false
-		outputEntry.Error.Message = msg
-
-		return &outputEntry
-	}
-
-	outputEntry.Success = true
-	outputEntry.Data = handlerOutput
-
-	return &outputEntry
 }
 
 type RunInput HandlerInput
 type RunOutput RequestResponseBody
 
 func Run(input *RunInput) (*RunOutput, error) {
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
 	if input.Session == nil {
 		return nil, erros.ErrBaseInvalida
 	}
 
-	handlerOutput, err := handler(&HandlerInput{
+	if err := consts.Validate(input.Session, ACTION, input.ActionInput); err != nil {
+		return nil, err
+	}
+
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Session:     input.Session,
-		ActionInput: input.ActionInput,
+		ActionInput: consts.Truncate(input.Session, input.ActionInput),
 	})
 	if err != nil {
 		return nil, err
@@ -150,6 +113,10 @@ type RequestHeader struct {
 	Action    string `json:"Action,omitempty"`
 }
 
+func (r *Request) SetSessionId(sessionId string) {
+	r.Header.SessionId = sessionId
+}
+
 type RequestBody struct {
 	*ActionInput
 }
@@ -168,49 +135,28 @@ type RequestResponseHeader struct {
 
 type RequestResponseBody struct{}
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
-			SessionId: input.Session.SessionId,
-			Action:    ACTION,
+			Action: ACTION,
 		},
 		Body: &RequestBody{
 			input.ActionInput,
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Session.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Session.Send(ctx, &session.SendInput{
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    &request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
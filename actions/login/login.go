@@ -1,16 +1,19 @@
 package login
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
 
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/transport"
 )
 
 var ACTION = "LOGIN"
 
+// Cada LOGIN processado abre uma sessão no servidor: repetir após uma resposta
+// perdida deixaria uma sessão aberta ocupando o MaxSessions.
+var IDEMPOTENT = false
+
 type ActionInput struct {
 	ImobId   *string `json:"IMOB_ID,omitempty"`   // Identificação da administradora.
 	UserId   *string `json:"USER_ID,omitempty"`   // Identificação do usuário.
@@ -21,8 +24,13 @@ type RunInput HandlerInput
 type RunOutput HandlerOutput
 
 func Run(input *RunInput) (*RunOutput, error) {
-	handlerOutput, err := handler(&HandlerInput{
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
+	handlerOutput, err := handler(ctx, &HandlerInput{
 		Endpoint:    input.Endpoint,
+		Client:      input.Client,
 		ActionInput: input.ActionInput,
 	})
 
@@ -31,6 +39,7 @@ func Run(input *RunInput) (*RunOutput, error) {
 
 type HandlerInput struct {
 	Endpoint string
+	Client   *transport.Client
 	*ActionInput
 }
 
@@ -78,7 +87,7 @@ type RequestResponseBody struct {
 	ServerDateTime *string `json:"ServerDateTime,omitempty"` // Horário do login no servidor.
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
 			Action: ACTION,
@@ -88,38 +97,25 @@ func handler(input *HandlerInput) (*HandlerOutput, error) {
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	var imobId string
+	if input.ImobId != nil {
+		imobId = *input.ImobId
 	}
 
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Client.Send(ctx, &transport.SendInput{
+		Endpoint:   input.Endpoint,
+		Action:     ACTION,
+		ImobId:     imobId,
+		Idempotent: IDEMPOTENT,
+		Request:    request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -1,27 +1,33 @@
 package logout
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"io"
-	"net/http"
 
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/transport"
 )
 
 var ACTION = "LOGOUT"
 
+var IDEMPOTENT = true
+
 type RunInput HandlerInput
 type RunOutput HandlerOutput
 
 func Run(input *RunInput) (*RunOutput, error) {
-	handlerOutput, err := handler((*HandlerInput)(input))
+	return RunContext(context.Background(), input)
+}
+
+func RunContext(ctx context.Context, input *RunInput) (*RunOutput, error) {
+	handlerOutput, err := handler(ctx, (*HandlerInput)(input))
 
 	return (*RunOutput)(handlerOutput), err
 }
 
 type HandlerInput struct {
 	Endpoint  string
+	Client    *transport.Client
 	SessionId string `json:"SessionId,omitempty"`
 }
 
@@ -53,7 +59,7 @@ type RequestResponseHeader struct {
 	Error     bool   `json:"Error,omitempty"`
 }
 
-func handler(input *HandlerInput) (*HandlerOutput, error) {
+func handler(ctx context.Context, input *HandlerInput) (*HandlerOutput, error) {
 	request := Request{
 		Header: &RequestHeader{
 			SessionId: input.SessionId,
@@ -61,38 +67,19 @@ func handler(input *HandlerInput) (*HandlerOutput, error) {
 		},
 	}
 
-	data, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequest("POST", input.Endpoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	client := &http.Client{}
-	res, err := client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	byteBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = erros.CheckResponseError(&byteBody)
+	byteBody, err := input.Client.Send(ctx, &transport.SendInput{
+		Endpoint:   input.Endpoint,
+		Action:     ACTION,
+		Idempotent: IDEMPOTENT,
+		Request:    request,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var requestResponse RequestResponse
 	if err := json.Unmarshal(byteBody, &requestResponse); err != nil {
-		return nil, err
+		return nil, erros.RespostaInvalida(err)
 	}
 
 	handlerOutput := HandlerOutput{
@@ -0,0 +1,236 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/itispx/goimobiliar/transport"
+)
+
+// Interaction é uma chamada gravada: o envelope enviado e a resposta bruta.
+type Interaction struct {
+	Action     string          `json:"action"`
+	Request    json.RawMessage `json:"request"`
+	StatusCode int             `json:"statusCode"`
+	Response   string          `json:"response"`
+}
+
+// Cassette é o conteúdo de um arquivo de fixture.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// ScrubFields são os campos substituídos por transport.Redacted antes de gravar
+// e ao comparar requisições: o SessionId e as senhas.
+var ScrubFields = transport.SecretFields
+
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, err
+	}
+
+	return &cassette, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+type RecorderInput struct {
+	Path   string         // Arquivo onde as interações são gravadas.
+	Next   transport.Doer // Cliente HTTP real. Valor default é http.DefaultClient.
+	Fields []string       // Campos adicionais a redigir, além de ScrubFields.
+}
+
+// Recorder é um transport.Doer que repassa as requisições para Next e grava
+// cada interação. Save grava o arquivo.
+type Recorder struct {
+	path   string
+	next   transport.Doer
+	fields []string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func NewRecorder(input *RecorderInput) *Recorder {
+	next := input.Next
+	if next == nil {
+		next = http.DefaultClient
+	}
+
+	return &Recorder{
+		path:   input.Path,
+		next:   next,
+		fields: append(append([]string{}, ScrubFields...), input.Fields...),
+	}
+}
+
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Action:     action(requestBody),
+		Request:    normalize(requestBody, r.fields),
+		StatusCode: res.StatusCode,
+		Response:   string(transport.Redact(responseBody, r.fields)),
+	})
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	return res, nil
+}
+
+// Save grava as interações no arquivo informado em RecorderInput.Path.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// Replayer é um transport.Doer que responde com as interações gravadas, sem
+// acessar a rede. A requisição é associada pela Action e pelo corpo, ignorando
+// os campos redigidos. Requisições repetidas consomem as interações na ordem
+// gravada; quando acabam, a última é repetida.
+type Replayer struct {
+	fields []string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+func NewReplayer(path string, fields ...string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{
+		fields:       append(append([]string{}, ScrubFields...), fields...),
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// ErrNaoGravada é retornado no corpo da resposta quando não há interação
+// gravada para a requisição. A resposta tem status 404 para não ser repetida.
+var ErrNaoGravada = errors.New("cassette: interação não gravada")
+
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	requestAction := action(requestBody)
+	normalized := normalize(requestBody, r.fields)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.interactions {
+		if interaction.Action != requestAction || !bytes.Equal(normalize(interaction.Request, r.fields), normalized) {
+			continue
+		}
+
+		last = i
+		if !r.used[i] {
+			r.used[i] = true
+			return response(req, interaction.StatusCode, interaction.Response), nil
+		}
+	}
+
+	if last >= 0 {
+		interaction := r.interactions[last]
+		return response(req, interaction.StatusCode, interaction.Response), nil
+	}
+
+	return response(req, http.StatusNotFound, ErrNaoGravada.Error()+": "+requestAction+" "+string(normalized)), nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func action(body []byte) string {
+	var envelope struct {
+		Header struct {
+			Action string `json:"Action"`
+		} `json:"Header"`
+	}
+	json.Unmarshal(body, &envelope)
+
+	return envelope.Header.Action
+}
+
+// normalize redige os campos e reescreve o JSON com as chaves ordenadas, para
+// que requisições equivalentes sejam comparadas byte a byte.
+func normalize(body []byte, fields []string) json.RawMessage {
+	return transport.Redact(body, fields)
+}
+
+func response(req *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,294 @@
+package cassette_test
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/actions/comerc_interessado_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_condominio_consultar"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_consultar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_consultar"
+	"github.com/itispx/goimobiliar/actions/lanctocc_proprietario_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_consultar"
+	"github.com/itispx/goimobiliar/actions/parametro_geral_consultar"
+	"github.com/itispx/goimobiliar/actions/tabela_consultar"
+	"github.com/itispx/goimobiliar/cassette"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/money"
+	"github.com/itispx/goimobiliar/session"
+	"github.com/itispx/goimobiliar/transport"
+)
+
+// replay abre uma sessão que responde com as interações gravadas em
+// testdata/<name>.json.
+func replay(t *testing.T, name string) *session.Session {
+	t.Helper()
+
+	rep, err := cassette.NewReplayer(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sess, err := session.NewSession(&session.NewInput{
+		Endpoint: "https://imobiliar.invalid/ws",
+		ImobId:   "DEMO",
+		UserId:   "INTEGRACAO",
+		UserPass: "outra senha", // Redigida na gravação: qualquer senha é aceita.
+		Client:   transport.New(&transport.NewInput{HTTPClient: rep, Retry: transport.NoRetry}),
+	})
+	if err != nil {
+		t.Fatalf("LOGIN: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := sess.EndSession(); err != nil {
+			t.Errorf("LOGOUT: %v", err)
+		}
+	})
+
+	return sess
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		family string
+		run    func(t *testing.T, sess *session.Session) error
+	}{
+		{"cadastro", func(t *testing.T, sess *session.Session) error {
+			pessoa, err := cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: ptr(1021)},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *pessoa.Nome != "MARIA DA SILVA" || *pessoa.CpfCnpj != doc.MustParse("012.345.678-90") ||
+				*pessoa.DataNascimento != imobdate.NewDate(1980, time.March, 15) || !bool(*pessoa.Ativo) ||
+				*pessoa.SenhaInternetMD5 != transport.Redacted {
+				t.Errorf("pessoa = %+v", pessoa)
+			}
+
+			return nil
+		}},
+		{"comerc", func(t *testing.T, sess *session.Session) error {
+			interessado, err := comerc_interessado_consultar.Run(&comerc_interessado_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &comerc_interessado_consultar.ActionInput{CodInteressado: ptr(88)},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *interessado.CpfCnpj != "11222333000181" || *interessado.TipoPessoa != enums.PessoaJuridica ||
+				*interessado.DataCadastro != imobdate.NewDate(2023, time.May, 2) {
+				t.Errorf("interessado = %+v", interessado)
+			}
+
+			return nil
+		}},
+		{"condom", func(t *testing.T, sess *session.Session) error {
+			condominio, err := condom_condominio_consultar.Run(&condom_condominio_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &condom_condominio_consultar.ActionInput{CodCondominio: ptr(310)},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *condominio.CNPJ != "11222333000181" || *condominio.UltimaCompetenciaDoc != imobdate.NewCompetencia(2024, time.March) ||
+				*condominio.TotaldeBlocos != 2 {
+				t.Errorf("condomínio = %+v", condominio)
+			}
+
+			return nil
+		}},
+		{"ctapag", func(t *testing.T, sess *session.Session) error {
+			lancamento, err := ctapag_lancamento_consultar.Run(&ctapag_lancamento_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &ctapag_lancamento_consultar.ActionInput{NumeroLancto: ptr(55012)},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *lancamento.ValorBruto != money.New(1250, 40) || *lancamento.ValorRetencaoIss != money.New(25, 1) ||
+				*lancamento.DataVencimento != imobdate.NewDate(2024, time.March, 20) {
+				t.Errorf("lançamento = %+v", lancamento)
+			}
+
+			return nil
+		}},
+		{"ctarec", func(t *testing.T, sess *session.Session) error {
+			boleto, err := ctarec_boleto_consultar.Run(&ctarec_boleto_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &ctarec_boleto_consultar.ActionInput{NossoNumero: ptr("0000000700123")},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *boleto.VlrDocumento != money.New(1890, 75) || *boleto.VlrMulta != money.MoneyTexto(money.New(37, 82)) ||
+				*boleto.OrigemCobranca != enums.CobrancaLocacao || bool(*boleto.DOCRetido) || bool(*boleto.Cancelado) {
+				t.Errorf("boleto = %+v", boleto)
+			}
+
+			return nil
+		}},
+		{"lanctocc", func(t *testing.T, sess *session.Session) error {
+			lancamento, err := lanctocc_proprietario_incluir.Run(&lanctocc_proprietario_incluir.RunInput{
+				Session: sess,
+				ActionInput: &lanctocc_proprietario_incluir.ActionInput{
+					CodPessoaProprietario: ptr(1021),
+					CodFilial:             ptr("001"),
+					Competencia:           ptr(imobdate.NewCompetencia(2024, time.April)),
+					TipoDocumento:         ptr(enums.DocumentoExtra),
+					NumeroDocumento:       ptr("REP-0424"),
+					LancaNaViradaParcelas: ptr("N"),
+					CodTaxa:               ptr(12),
+					Valor:                 ptr(money.New(350, 0)),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *lancamento.NumeroLancto != 90001 {
+				t.Errorf("lançamento = %+v", lancamento)
+			}
+
+			return nil
+		}},
+		{"locacao", func(t *testing.T, sess *session.Session) error {
+			imovel, err := locacao_imovel_consultar.Run(&locacao_imovel_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &locacao_imovel_consultar.ActionInput{CodImovel: ptr(4410)},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *imovel.Logradouro != "IPIRANGA" || *imovel.Numero != 1500 || *imovel.DataInclusao != imobdate.NewDate(2019, time.September, 18) {
+				t.Errorf("imóvel = %+v", imovel)
+			}
+
+			return nil
+		}},
+		{"geral", func(t *testing.T, sess *session.Session) error {
+			tabela, err := tabela_consultar.Run(&tabela_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &tabela_consultar.ActionInput{Tabela: ptr("TIPO_LOGRADOURO")},
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(*tabela.Itens) != 2 || *(*tabela.Itens)[0].Descricao != "AVENIDA" {
+				t.Errorf("tabela = %+v", tabela)
+			}
+
+			parametro, err := parametro_geral_consultar.Run(&parametro_geral_consultar.RunInput{
+				Session:     sess,
+				ActionInput: &parametro_geral_consultar.ActionInput{Secao: ptr("BOLETO"), Parametro: ptr("DIAS_PROTESTO")},
+			})
+			if err != nil {
+				return err
+			}
+
+			if *parametro.Valor != "30" {
+				t.Errorf("parâmetro = %+v", parametro)
+			}
+
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.family, func(t *testing.T) {
+			if err := tt.run(t, replay(t, tt.family)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReplayNaoGravada(t *testing.T) {
+	sess := replay(t, "cadastro")
+
+	// Outra entrada não corresponde à requisição gravada.
+	_, err := cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+		Session:     sess,
+		ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: ptr(1022)},
+	})
+
+	var httpError *erros.HTTPError
+	if !errors.As(err, &httpError) || httpError.StatusCode != http.StatusNotFound ||
+		!strings.Contains(httpError.Body, cassette.ErrNaoGravada.Error()) {
+		t.Errorf("erro = %v, esperado ErrNaoGravada", err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	srv := imobtest.New(&imobtest.NewInput{UserPass: "segredo"})
+	defer srv.Close()
+
+	srv.Respond(cadastro_pessoa_consultar.ACTION, map[string]any{"CodPessoa": 1, "Nome": "Fulano", "SenhaInternetMD5": "ABC"})
+
+	path := filepath.Join(t.TempDir(), "gravacao.json")
+	rec := cassette.NewRecorder(&cassette.RecorderInput{Path: path, Fields: []string{"Nome"}})
+
+	input := srv.NewSessionInput()
+	input.Client = transport.New(&transport.NewInput{HTTPClient: rec})
+
+	sess, err := session.NewSession(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+		Session:     sess,
+		ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: ptr(1)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sess.EndSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	gravado, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actions []string
+	for _, interaction := range gravado.Interactions {
+		actions = append(actions, interaction.Action)
+
+		for _, secret := range []string{"segredo", "SESSION-", `"ABC"`, "Fulano"} {
+			if strings.Contains(string(interaction.Request), secret) || strings.Contains(interaction.Response, secret) {
+				t.Errorf("%s: %q não foi redigido", interaction.Action, secret)
+			}
+		}
+	}
+
+	if got := strings.Join(actions, ","); got != "LOGIN,CADASTRO_PESSOA_CONSULTAR,LOGOUT" {
+		t.Errorf("interações = %s", got)
+	}
+}
@@ -0,0 +1,52 @@
+package consts
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultQtdeLinhas é o tamanho do segmento usado por All quando a entrada
+// não informa QtdeLinhas.
+const DefaultQtdeLinhas = 100
+
+// ProximasLinhas é o valor de ProximasLinhas que solicita o próximo segmento.
+const ProximasLinhas = "S"
+
+// FetchFunc busca um segmento de uma pesquisa. proximas indica que o segmento
+// seguinte ao último retornado deve ser solicitado com ProximasLinhas.
+type FetchFunc[Item any] func(ctx context.Context, proximas bool) ([]Item, error)
+
+// All percorre todos os itens de uma pesquisa segmentada, buscando segmentos
+// de qtdeLinhas itens até receber um segmento incompleto ou vazio. Um erro
+// é entregue junto com o valor zero de Item e encerra a iteração.
+func All[Item any](ctx context.Context, qtdeLinhas int, fetch FetchFunc[Item]) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		for proximas := false; ; proximas = true {
+			items, err := fetch(ctx, proximas)
+			if err != nil {
+				var zero Item
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || len(items) < qtdeLinhas {
+				return
+			}
+		}
+	}
+}
+
+// QtdeLinhas retorna o tamanho de segmento informado ou DefaultQtdeLinhas.
+func QtdeLinhas(qtdeLinhas *int) int {
+	if qtdeLinhas == nil || *qtdeLinhas <= 0 {
+		return DefaultQtdeLinhas
+	}
+
+	return *qtdeLinhas
+}
@@ -1,8 +1,14 @@
 package consts
 
+import (
+	"github.com/itispx/goimobiliar/session"
+	"github.com/itispx/goimobiliar/transport"
+)
+
 type RunMultiOutput[T any] []*RunMultiOutputEntry[T]
 
 type RunMultiOutputEntry[T any] struct {
+	Index   int    `json:"index"` // Posição da entrada correspondente em RunMultiInput.Entries.
 	Success bool   `json:"success,omitempty"`
 	ImobId  string `json:"imobId,omitempty"`
 	Data    T      `json:"data,omitempty"`
@@ -14,8 +20,12 @@ type Error struct {
 }
 
 type RunMultiInput[T any] struct {
-	Parallel bool
-	Entries  []*RunMultiInputEntry[T]
+	Parallel       bool
+	MaxConcurrency int               // Limite de entradas processadas ao mesmo tempo quando Parallel é true. Se 0, não há limite.
+	Client         *transport.Client // Opcional. Cliente usado nas sessões abertas pelo RunMulti.
+	Pool           *session.Pool     // Opcional. Se informado, as sessões são emprestadas do pool em vez de abertas pelo RunMulti.
+	Entries        []*RunMultiInputEntry[T]
+	OnProgress     func(done, total int) // Opcional. Chamado após cada entrada terminar, uma chamada por vez.
 }
 
 type RunMultiInputEntry[T any] struct {
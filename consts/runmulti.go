@@ -0,0 +1,169 @@
+package consts
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/session"
+)
+
+// RunFunc executa uma action com a sessão já autenticada da entrada.
+type RunFunc[In, Out any] func(ctx context.Context, sess *session.Session, input In) (Out, error)
+
+func RunMulti[In, Out any](input *RunMultiInput[In], run RunFunc[In, Out]) (RunMultiOutput[Out], error) {
+	return RunMultiContext(context.Background(), input, run)
+}
+
+// RunMultiContext executa run para cada entrada, compartilhando sessões entre
+// entradas com as mesmas credenciais. O resultado de cada entrada fica na mesma
+// posição de input.Entries. Após o cancelamento de ctx nenhuma nova entrada é
+// iniciada e ctx.Err() é retornado.
+func RunMultiContext[In, Out any](ctx context.Context, input *RunMultiInput[In], run RunFunc[In, Out]) (RunMultiOutput[Out], error) {
+	if input == nil {
+		return nil, erros.ErrCampoVazio("input")
+	}
+
+	output := make(RunMultiOutput[Out], len(input.Entries))
+
+	runMulti(ctx, input, run, func(outputEntry *RunMultiOutputEntry[Out]) {
+		output[outputEntry.Index] = outputEntry
+	})
+
+	return output, ctx.Err()
+}
+
+// RunMultiStream funciona como RunMultiContext, mas entrega cada resultado
+// assim que a entrada termina, junto com sua posição em input.Entries.
+// Interromper o range cancela as entradas ainda não iniciadas.
+func RunMultiStream[In, Out any](ctx context.Context, input *RunMultiInput[In], run RunFunc[In, Out]) iter.Seq2[int, *RunMultiOutputEntry[Out]] {
+	return func(yield func(int, *RunMultiOutputEntry[Out]) bool) {
+		if input == nil {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan *RunMultiOutputEntry[Out])
+
+		go func() {
+			defer close(results)
+
+			runMulti(ctx, input, run, func(outputEntry *RunMultiOutputEntry[Out]) {
+				results <- outputEntry
+			})
+		}()
+
+		for outputEntry := range results {
+			if !yield(outputEntry.Index, outputEntry) {
+				cancel()
+				for range results {
+				}
+				return
+			}
+		}
+	}
+}
+
+// runMulti executa as entradas e chama emit, uma vez por vez, com o resultado
+// de cada uma assim que termina.
+func runMulti[In, Out any](ctx context.Context, input *RunMultiInput[In], run RunFunc[In, Out], emit func(*RunMultiOutputEntry[Out])) {
+	sessions := newRunMultiSessions(input.Client, input.Pool)
+	defer sessions.Close()
+
+	total := len(input.Entries)
+	done := 0
+
+	var mu sync.Mutex
+	finish := func(outputEntry *RunMultiOutputEntry[Out]) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		emit(outputEntry)
+
+		done++
+		if input.OnProgress != nil {
+			input.OnProgress(done, total)
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, input.concurrency())
+
+	for i, entry := range input.Entries {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if err := ctx.Err(); err != nil {
+			finish(&RunMultiOutputEntry[Out]{
+				Index:  i,
+				ImobId: entry.ImobId,
+				Error:  Error{Message: err.Error()},
+			})
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, entry *RunMultiInputEntry[In]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			outputEntry := runMultiEntry(ctx, sessions, entry, run)
+			outputEntry.Index = i
+			finish(outputEntry)
+		}(i, entry)
+	}
+
+	wg.Wait()
+}
+
+func runMultiEntry[In, Out any](ctx context.Context, sessions *runMultiSessions, input *RunMultiInputEntry[In], run RunFunc[In, Out]) *RunMultiOutputEntry[Out] {
+	outputEntry := RunMultiOutputEntry[Out]{
+		ImobId: input.ImobId,
+	}
+
+	sess, release, err := sessions.Get(ctx, &session.NewInput{
+		Endpoint: input.Endpoint,
+		ImobId:   input.ImobId,
+		UserId:   input.UserId,
+		UserPass: input.UserPass,
+	})
+	if err != nil {
+		outputEntry.Success = false
+		outputEntry.Error.Message = err.Error()
+
+		return &outputEntry
+	}
+
+	defer release()
+
+	data, err := run(ctx, sess, input.Input)
+	if err != nil {
+		outputEntry.Success = false
+		outputEntry.Error.Message = err.Error()
+
+		return &outputEntry
+	}
+
+	outputEntry.Success = true
+	outputEntry.Data = data
+
+	return &outputEntry
+}
+
+// concurrency retorna quantas entradas podem ser processadas ao mesmo tempo.
+func (r *RunMultiInput[T]) concurrency() int {
+	if !r.Parallel {
+		return 1
+	}
+
+	if r.MaxConcurrency > 0 {
+		return r.MaxConcurrency
+	}
+
+	return max(len(r.Entries), 1)
+}
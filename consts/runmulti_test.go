@@ -0,0 +1,420 @@
+package consts_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/session"
+)
+
+// pessoas responde CADASTRO_PESSOA_CONSULTAR com o próprio CodPessoa, após
+// delay, e registra quantas chamadas ficaram em andamento ao mesmo tempo.
+type pessoas struct {
+	calls       atomic.Int32
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (p *pessoas) handle(delay func(codPessoa int) time.Duration) imobtest.HandlerFunc {
+	return func(req *imobtest.Request) (any, error) {
+		var body cadastro_pessoa_consultar.ActionInput
+		if err := req.Decode(&body); err != nil {
+			return nil, err
+		}
+
+		p.calls.Add(1)
+		n := p.inFlight.Add(1)
+		defer p.inFlight.Add(-1)
+
+		for {
+			m := p.maxInFlight.Load()
+			if n <= m || p.maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(delay(*body.CodPessoa))
+
+		return map[string]any{"CodPessoa": *body.CodPessoa}, nil
+	}
+}
+
+func consultar(ctx context.Context, sess *session.Session, codPessoa int) (*cadastro_pessoa_consultar.RunOutput, error) {
+	return cadastro_pessoa_consultar.RunContext(ctx, &cadastro_pessoa_consultar.RunInput{
+		Session:     sess,
+		ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: &codPessoa},
+	})
+}
+
+func entries(srv *imobtest.Server, n int) []*consts.RunMultiInputEntry[int] {
+	credenciais := srv.NewSessionInput()
+
+	list := make([]*consts.RunMultiInputEntry[int], n)
+	for i := range list {
+		list[i] = &consts.RunMultiInputEntry[int]{
+			Endpoint: credenciais.Endpoint,
+			ImobId:   credenciais.ImobId,
+			UserId:   credenciais.UserId,
+			UserPass: credenciais.UserPass,
+			Input:    i,
+		}
+	}
+
+	return list
+}
+
+func count(srv *imobtest.Server, action string) int {
+	n := 0
+	for _, req := range srv.Requests() {
+		if req.Action == action {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestRunMultiOrdem(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	// As primeiras entradas demoram mais e terminam por último.
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(codPessoa int) time.Duration {
+		return time.Duration(50-codPessoa) * 100 * time.Microsecond
+	}))
+
+	output, err := consts.RunMultiContext(context.Background(), &consts.RunMultiInput[int]{
+		Parallel:       true,
+		MaxConcurrency: 8,
+		Entries:        entries(srv, 50),
+	}, consultar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output) != 50 {
+		t.Fatalf("%d resultados, esperado 50", len(output))
+	}
+
+	for i, outputEntry := range output {
+		if outputEntry.Index != i || !outputEntry.Success || *outputEntry.Data.CodPessoa != i {
+			t.Errorf("resultado %d = %+v", i, outputEntry)
+		}
+	}
+
+	if got := count(srv, "LOGIN"); got != 1 {
+		t.Errorf("%d LOGINs, esperado 1", got)
+	}
+
+	if got := srv.Sessions(); got != 0 {
+		t.Errorf("%d sessões abertas ao fim do lote, esperado 0", got)
+	}
+}
+
+func TestRunMultiConcorrencia(t *testing.T) {
+	tests := []struct {
+		name           string
+		parallel       bool
+		maxConcurrency int
+		wantMax        int32
+	}{
+		{"sequencial", false, 0, 1},
+		{"sequencial ignora MaxConcurrency", false, 4, 1},
+		{"MaxConcurrency", true, 3, 3},
+		{"sem limite", true, 0, 12},
+	}
+
+	for _, tt := range tests {
+		srv := imobtest.New(nil)
+
+		var p pessoas
+		srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration {
+			return 10 * time.Millisecond
+		}))
+
+		output, err := consts.RunMultiContext(context.Background(), &consts.RunMultiInput[int]{
+			Parallel:       tt.parallel,
+			MaxConcurrency: tt.maxConcurrency,
+			Entries:        entries(srv, 12),
+		}, consultar)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+
+		for _, outputEntry := range output {
+			if !outputEntry.Success {
+				t.Errorf("%s: entrada %d: %s", tt.name, outputEntry.Index, outputEntry.Error.Message)
+			}
+		}
+
+		if got := p.maxInFlight.Load(); got > tt.wantMax {
+			t.Errorf("%s: %d entradas ao mesmo tempo, limite %d", tt.name, got, tt.wantMax)
+		}
+
+		if got := p.calls.Load(); got != 12 {
+			t.Errorf("%s: %d chamadas, esperado 12", tt.name, got)
+		}
+
+		srv.Close()
+	}
+}
+
+func TestRunMultiCancelado(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration { return 0 }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	output, err := consts.RunMultiContext(ctx, &consts.RunMultiInput[int]{
+		Entries: entries(srv, 10),
+		OnProgress: func(done, total int) {
+			if done == 3 {
+				cancel()
+			}
+		},
+	}, consultar)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("erro = %v, esperado context.Canceled", err)
+	}
+
+	if got := p.calls.Load(); got != 3 {
+		t.Errorf("%d entradas iniciadas, esperado 3", got)
+	}
+
+	for i, outputEntry := range output {
+		if outputEntry.Index != i || outputEntry.Success != (i < 3) {
+			t.Errorf("resultado %d = %+v", i, outputEntry)
+		}
+
+		if i >= 3 && outputEntry.Error.Message != context.Canceled.Error() {
+			t.Errorf("resultado %d: erro = %q, esperado %q", i, outputEntry.Error.Message, context.Canceled)
+		}
+	}
+}
+
+func TestRunMultiLoginPorCredencial(t *testing.T) {
+	a, b := imobtest.New(nil), imobtest.New(nil)
+	defer a.Close()
+	defer b.Close()
+
+	var p pessoas
+	for _, srv := range []*imobtest.Server{a, b} {
+		srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration { return 0 }))
+	}
+
+	// 500 entradas intercaladas entre duas credenciais e uma senha errada.
+	entriesA, entriesB := entries(a, 250), entries(b, 250)
+
+	var list []*consts.RunMultiInputEntry[int]
+	for i := range entriesA {
+		list = append(list, entriesA[i], entriesB[i])
+	}
+
+	errada := *list[0]
+	errada.UserPass = "ERRADA"
+	list = append(list, &errada, &errada)
+
+	output, err := consts.RunMultiContext(context.Background(), &consts.RunMultiInput[int]{
+		Parallel:       true,
+		MaxConcurrency: 32,
+		Entries:        list,
+	}, consultar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := 0
+	for _, outputEntry := range output {
+		if !outputEntry.Success {
+			failed++
+		}
+	}
+
+	if failed != 2 || p.calls.Load() != 500 {
+		t.Errorf("%d entradas com erro e %d chamadas, esperado 2 e 500", failed, p.calls.Load())
+	}
+
+	// Uma tentativa de LOGIN por credencial, inclusive a rejeitada.
+	if got := count(a, "LOGIN") + count(b, "LOGIN"); got != 3 {
+		t.Errorf("%d LOGINs, esperado 3", got)
+	}
+
+	if got := count(a, "LOGOUT") + count(b, "LOGOUT"); got != 2 {
+		t.Errorf("%d LOGOUTs, esperado 2", got)
+	}
+}
+
+func TestRunMultiPool(t *testing.T) {
+	srv := imobtest.New(&imobtest.NewInput{MaxSessions: 2})
+	defer srv.Close()
+
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration {
+		return time.Millisecond
+	}))
+
+	pool := session.NewPool(nil)
+	defer pool.Close()
+
+	output, err := consts.RunMultiContext(context.Background(), &consts.RunMultiInput[int]{
+		Parallel: true,
+		Pool:     pool,
+		Entries:  entries(srv, 20),
+	}, consultar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, outputEntry := range output {
+		if !outputEntry.Success {
+			t.Errorf("entrada %d: %s", outputEntry.Index, outputEntry.Error.Message)
+		}
+	}
+
+	if got := count(srv, "LOGIN"); got > 2 {
+		t.Errorf("%d LOGINs, esperado no máximo 2", got)
+	}
+
+	// As sessões voltam para o pool, sem LOGOUT.
+	if got := count(srv, "LOGOUT"); got != 0 {
+		t.Errorf("%d LOGOUTs, esperado 0", got)
+	}
+}
+
+func TestRunMultiOnProgress(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration { return time.Millisecond }))
+
+	tests := []struct {
+		name     string
+		parallel bool
+	}{
+		{"sequencial", false},
+		{"paralelo", true},
+	}
+
+	for _, tt := range tests {
+		var progress [][2]int
+		if _, err := consts.RunMultiContext(context.Background(), &consts.RunMultiInput[int]{
+			Parallel: tt.parallel,
+			Entries:  entries(srv, 30),
+			OnProgress: func(done, total int) {
+				progress = append(progress, [2]int{done, total})
+			},
+		}, consultar); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		for i, got := range progress {
+			if got != [2]int{i + 1, 30} {
+				t.Errorf("%s: OnProgress %d = %v, esperado (%d, 30)", tt.name, i, got, i+1)
+			}
+		}
+
+		if len(progress) != 30 {
+			t.Errorf("%s: OnProgress chamado %d vezes, esperado 30", tt.name, len(progress))
+		}
+	}
+}
+
+func TestRunMultiStream(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration {
+		return 5 * time.Millisecond
+	}))
+
+	input := &consts.RunMultiInput[int]{Parallel: true, MaxConcurrency: 4, Entries: entries(srv, 20)}
+
+	seen := map[int]bool{}
+	for i, outputEntry := range consts.RunMultiStream(context.Background(), input, consultar) {
+		if i != outputEntry.Index || !outputEntry.Success || *outputEntry.Data.CodPessoa != i || seen[i] {
+			t.Errorf("resultado %d = %+v", i, outputEntry)
+		}
+		seen[i] = true
+	}
+
+	if len(seen) != 20 {
+		t.Errorf("%d resultados, esperado 20", len(seen))
+	}
+}
+
+func TestRunMultiStreamInterrompido(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	var p pessoas
+	srv.Handle(cadastro_pessoa_consultar.ACTION, p.handle(func(int) time.Duration {
+		return 5 * time.Millisecond
+	}))
+
+	before := runtime.NumGoroutine()
+
+	var mu sync.Mutex
+	var progress int
+	input := &consts.RunMultiInput[int]{
+		Parallel:       true,
+		MaxConcurrency: 2,
+		Entries:        entries(srv, 100),
+		OnProgress: func(done, total int) {
+			mu.Lock()
+			progress = done
+			mu.Unlock()
+		},
+	}
+
+	received := 0
+	for range consts.RunMultiStream(context.Background(), input, consultar) {
+		received++
+		if received == 3 {
+			break
+		}
+	}
+
+	// Ao sair do range, as entradas em andamento já terminaram, as demais
+	// foram canceladas e a sessão foi encerrada.
+	if got := p.calls.Load(); got >= 10 {
+		t.Errorf("%d entradas iniciadas após interromper o range", got)
+	}
+
+	if got := p.inFlight.Load(); got != 0 {
+		t.Errorf("%d entradas ainda em andamento", got)
+	}
+
+	if got := srv.Sessions(); got != 0 {
+		t.Errorf("%d sessões abertas, esperado 0", got)
+	}
+
+	mu.Lock()
+	if progress != 100 {
+		t.Errorf("OnProgress parou em %d, esperado 100 com as entradas canceladas", progress)
+	}
+	mu.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := runtime.NumGoroutine(); got > before {
+		t.Errorf("%d goroutines após interromper o range, antes eram %d", got, before)
+	}
+}
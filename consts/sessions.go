@@ -0,0 +1,105 @@
+package consts
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/itispx/goimobiliar/session"
+	"github.com/itispx/goimobiliar/transport"
+)
+
+// runMultiSessions compartilha sessões entre as entradas de um RunMulti.
+// Entradas com as mesmas credenciais usam uma única sessão, aberta no primeiro
+// uso e encerrada em Close. Se um pool for informado, cada entrada pega uma
+// sessão emprestada do pool e a devolve ao terminar.
+type runMultiSessions struct {
+	client *transport.Client
+	pool   *session.Pool
+
+	mu     sync.Mutex
+	groups map[runMultiSessionKey]*runMultiSession
+}
+
+type runMultiSessionKey struct {
+	endpoint string
+	imobId   string
+	userId   string
+	userPass string
+}
+
+type runMultiSession struct {
+	once sync.Once
+	sess *session.Session
+	err  error
+}
+
+func newRunMultiSessions(client *transport.Client, pool *session.Pool) *runMultiSessions {
+	return &runMultiSessions{
+		client: client,
+		pool:   pool,
+		groups: map[runMultiSessionKey]*runMultiSession{},
+	}
+}
+
+// Get retorna a sessão das credenciais informadas. A função release deve ser
+// chamada quando a entrada terminar de usar a sessão.
+func (r *runMultiSessions) Get(ctx context.Context, input *session.NewInput) (*session.Session, func(), error) {
+	if input.Client == nil {
+		input.Client = r.client
+	}
+
+	if r.pool != nil {
+		sess, err := r.pool.Get(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sess, func() { r.pool.Put(sess) }, nil
+	}
+
+	key := runMultiSessionKey{
+		endpoint: input.Endpoint,
+		imobId:   input.ImobId,
+		userId:   input.UserId,
+		userPass: input.UserPass,
+	}
+
+	r.mu.Lock()
+	group, ok := r.groups[key]
+	if !ok {
+		group = &runMultiSession{}
+		r.groups[key] = group
+	}
+	r.mu.Unlock()
+
+	group.once.Do(func() {
+		group.sess, group.err = session.NewSessionContext(ctx, input)
+	})
+	if group.err != nil {
+		return nil, nil, group.err
+	}
+
+	return group.sess, func() {}, nil
+}
+
+// Close encerra as sessões abertas por Get. Sessões emprestadas do pool não
+// são afetadas.
+func (r *runMultiSessions) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, group := range r.groups {
+		if group.sess == nil {
+			continue
+		}
+
+		if err := group.sess.EndSession(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.groups = map[runMultiSessionKey]*runMultiSession{}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,336 @@
+package consts
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/session"
+)
+
+// MensagemObrigatorio é a mensagem dos campos obrigatórios não informados.
+const MensagemObrigatorio = "campo obrigatório não informado"
+
+// Validate verifica a entrada de uma action antes do envio, conforme a tag
+// validate de cada campo:
+//
+//   - required: o campo não pode ser nil, texto vazio, lista vazia ou data
+//     zerada;
+//   - max=N: textos com no máximo N caracteres (String(N) e Phone(N));
+//   - precision=P,scale=S: números com no máximo P dígitos, dos quais S
+//     decimais (Number(P,S)).
+//
+// Campos de tipos com método Valid, como os dos pacotes enums e doc, são
+// rejeitados quando o valor informado não é válido. Valores em branco contam
+// como não informados e só são rejeitados por required.
+//
+// Structs e listas aninhadas também são verificadas. Todos os campos rejeitados
+// são retornados juntos em um *erros.ValidationError. Se sess.TruncateText for
+// verdadeiro, textos longos e números com casas decimais a mais são aceitos; a
+// entrada não é alterada, e Truncate retorna a cópia ajustada a enviar.
+func Validate(sess *session.Session, action string, input any) error {
+	v := reflect.ValueOf(input)
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	validator := validator{truncate: sess != nil && sess.TruncateText}
+	validator.validateStruct(v, "")
+
+	if len(validator.erros) > 0 {
+		return &erros.ValidationError{Action: action, Erros: validator.erros}
+	}
+
+	return nil
+}
+
+// Truncate retorna uma cópia de input com os textos acima de max truncados e os
+// números com casas decimais a mais arredondados, para o envio com
+// sess.TruncateText. A entrada de quem chamou nunca é alterada. Sem
+// sess.TruncateText, retorna a própria input.
+func Truncate[T any](sess *session.Session, input *T) *T {
+	if input == nil || sess == nil || !sess.TruncateText {
+		return input
+	}
+
+	output := clone(reflect.ValueOf(input))
+
+	v := output.Elem()
+	if v.Kind() == reflect.Struct {
+		validator := validator{truncate: true, write: true}
+		validator.validateStruct(v, "")
+	}
+
+	return output.Interface().(*T)
+}
+
+type validator struct {
+	truncate bool // Aceita textos longos e casas decimais a mais.
+	write    bool // Trunca e arredonda os valores, na cópia criada por Truncate.
+	erros    []erros.Erro
+}
+
+func (r *validator) reject(campo, mensagem string) {
+	r.erros = append(r.erros, erros.Erro{Campo: campo, Mensagem: mensagem})
+}
+
+func (r *validator) validateStruct(v reflect.Value, path string) {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := path + fieldName(field)
+		value := v.Field(i)
+		rules := parseRules(field.Tag.Get("validate"))
+
+		if _, ok := rules["required"]; ok && empty(value) {
+			r.reject(name, MensagemObrigatorio)
+		}
+
+		if max, ok := rules["max"]; ok {
+			r.validateLength(value, name, max)
+		}
+
+		if precision, ok := rules["precision"]; ok {
+			r.validateNumber(value, name, precision, rules["scale"])
+		}
+
+		r.validateCode(value, name)
+
+		r.validateNested(value, name)
+	}
+}
+
+func (r *validator) validateNested(v reflect.Value, path string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		r.validateStruct(v, path+".")
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			r.validateNested(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (r *validator) validateCode(v reflect.Value, name string) {
+	v, ok := deref(v)
+	if !ok || (v.Kind() == reflect.String && empty(v)) {
+		return
+	}
+
+	if code, ok := v.Interface().(interface{ Valid() bool }); ok && !code.Valid() {
+		r.reject(name, fmt.Sprintf("valor inválido: %q", fmt.Sprint(v.Interface())))
+	}
+}
+
+func (r *validator) validateLength(v reflect.Value, name string, max int) {
+	v, ok := deref(v)
+	if !ok || v.Kind() != reflect.String {
+		return
+	}
+
+	text := v.String()
+	length := utf8.RuneCountInString(text)
+	if length <= max {
+		return
+	}
+
+	if r.truncate {
+		if r.write && v.CanSet() {
+			v.SetString(string([]rune(text)[:max]))
+		}
+		return
+	}
+
+	r.reject(name, fmt.Sprintf("texto com %d caracteres excede o máximo de %d", length, max))
+}
+
+func (r *validator) validateNumber(v reflect.Value, name string, precision, scale int) {
+	v, ok := deref(v)
+	if !ok {
+		return
+	}
+
+	var number float64
+	switch value := v.Interface().(type) {
+	case interface{ Float64() float64 }:
+		// Tipos decimais, como money.Money, que guarda centavos em um int64.
+		number = value.Float64()
+	default:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number = float64(v.Int())
+		case reflect.Float32, reflect.Float64:
+			number = v.Float()
+		default:
+			return
+		}
+	}
+
+	if scale > 0 {
+		factor := math.Pow10(scale)
+		rounded := math.Round(number*factor) / factor
+		if math.Abs(number-rounded) > 1e-9*math.Max(1, math.Abs(number)) {
+			if !r.truncate || !v.CanFloat() {
+				r.reject(name, fmt.Sprintf("número excede %d casas decimais", scale))
+				return
+			}
+			if r.write && v.CanSet() {
+				v.SetFloat(rounded)
+			}
+			number = rounded
+		}
+	}
+
+	if math.Abs(number) < math.Pow10(precision-scale) {
+		return
+	}
+
+	if scale > 0 {
+		r.reject(name, fmt.Sprintf("número excede %d dígitos inteiros", precision-scale))
+	} else {
+		r.reject(name, fmt.Sprintf("número excede %d dígitos", precision))
+	}
+}
+
+// parseRules lê a tag validate, como "required,precision=14,scale=2".
+func parseRules(tag string) map[string]int {
+	rules := map[string]int{}
+	if tag == "" {
+		return rules
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		n, _ := strconv.Atoi(value)
+		rules[key] = n
+	}
+
+	return rules
+}
+
+// clone copia v em profundidade, seguindo ponteiros, structs, listas e mapas.
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(clone(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(clone(v.Elem()))
+		return c
+	case reflect.Struct:
+		// Campos não exportados, como o time.Time de imobdate.Date, são
+		// copiados como estão.
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(clone(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(clone(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(clone(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), clone(iter.Value()))
+		}
+		return c
+	}
+
+	return v
+}
+
+func deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+
+	return v, true
+}
+
+func empty(v reflect.Value) bool {
+	v, ok := deref(v)
+	if !ok {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		// Datas e competências zeradas, como imobdate.Date{}.
+		if zero, ok := v.Interface().(interface{ IsZero() bool }); ok {
+			return zero.IsZero()
+		}
+	}
+
+	return false
+}
+
+// fieldName retorna o nome do campo no JSON enviado ao webservice.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
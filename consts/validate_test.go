@@ -0,0 +1,253 @@
+package consts
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/money"
+	"github.com/itispx/goimobiliar/session"
+)
+
+type parcelaTeste struct {
+	Valor      *money.Money         `json:"Valor" validate:"required"`
+	Vencimento imobdate.Date        `json:"Vencimento" validate:"required"`
+	Debito     *enums.DebitoCredito `json:"DebCred"`
+}
+
+type entradaTeste struct {
+	Codigo   *int              `json:"Codigo" validate:"required"`
+	Nome     *string           `json:"Nome" validate:"required"`
+	Tipo     *enums.TipoPessoa `json:"TipoPessoa"`
+	Parcelas []parcelaTeste    `json:"Parcelas" validate:"required"`
+	Opcional *string           `json:"Opcional,omitempty"`
+}
+
+func TestValidateRequired(t *testing.T) {
+	codigo, nome, vazio := 10, "Fulano", "   "
+	valor := money.New(100, 0)
+	vencimento := imobdate.NewDate(2024, time.January, 10)
+	juridica, invalida := enums.PessoaJuridica, enums.TipoPessoa("s")
+	debito, invalido := enums.Debito, enums.DebitoCredito("X")
+
+	tests := []struct {
+		name  string
+		input any
+		want  []string
+	}{
+		{"completa", &entradaTeste{
+			Codigo: &codigo, Nome: &nome, Tipo: &juridica,
+			Parcelas: []parcelaTeste{{Valor: &valor, Vencimento: vencimento, Debito: &debito}},
+		}, nil},
+		{"vazia", &entradaTeste{}, []string{"Codigo", "Nome", "Parcelas"}},
+		{"nil", (*entradaTeste)(nil), []string{"Codigo", "Nome", "Parcelas"}},
+		{"texto em branco", &entradaTeste{
+			Codigo: &codigo, Nome: &vazio,
+			Parcelas: []parcelaTeste{{Valor: &valor, Vencimento: vencimento}},
+		}, []string{"Nome"}},
+		{"lista aninhada", &entradaTeste{
+			Codigo: &codigo, Nome: &nome,
+			Parcelas: []parcelaTeste{{Valor: &valor, Vencimento: vencimento}, {}},
+		}, []string{"Parcelas[1].Valor", "Parcelas[1].Vencimento"}},
+		{"códigos inválidos", &entradaTeste{
+			Codigo: &codigo, Nome: &nome, Tipo: &invalida,
+			Parcelas: []parcelaTeste{{Valor: &valor, Vencimento: vencimento, Debito: &invalido}},
+		}, []string{"TipoPessoa", "Parcelas[0].DebCred"}},
+		{"não struct", "texto", nil},
+		{"nil sem tipo", nil, nil},
+	}
+
+	for _, tt := range tests {
+		err := Validate(nil, "TESTE", tt.input)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s: erro inesperado: %v", tt.name, err)
+			}
+			continue
+		}
+
+		var validationError *erros.ValidationError
+		if !errors.As(err, &validationError) || !errors.Is(err, erros.ErrValidacao) {
+			t.Errorf("%s: erro = %v, esperado *erros.ValidationError", tt.name, err)
+			continue
+		}
+
+		if got := validationError.Campos(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: campos = %v, esperado %v", tt.name, got, tt.want)
+		}
+
+		if validationError.Action != "TESTE" {
+			t.Errorf("%s: action = %q", tt.name, validationError.Action)
+		}
+	}
+}
+
+type documentoTeste struct {
+	CpfCnpj   *doc.CPFCNPJ      `json:"CpfCnpj"`
+	Cnpj      *doc.CPFCNPJTexto `json:"Cnpj" validate:"required"`
+	Tipo      *enums.TipoPessoa `json:"TipoPessoa"`
+	Documento doc.CPFCNPJ       `json:"Documento"`
+}
+
+func TestValidateDocumento(t *testing.T) {
+	cnpj := doc.CPFCNPJTexto("11222333000181")
+
+	tests := []struct {
+		name  string
+		input documentoTeste
+		want  []string
+	}{
+		{"válidos", documentoTeste{CpfCnpj: ptrDoc("01234567890"), Cnpj: &cnpj, Documento: "11222333000181"}, nil},
+		{"com máscara", documentoTeste{
+			CpfCnpj: ptrDoc("012.345.678-90"), Cnpj: &cnpj, Documento: "11.222.333/0001-81",
+		}, nil},
+		{"em branco", documentoTeste{
+			CpfCnpj: ptrDoc(""), Cnpj: &cnpj, Tipo: &[]enums.TipoPessoa{""}[0], Documento: "  ",
+		}, nil},
+		{"obrigatório em branco", documentoTeste{Cnpj: &[]doc.CPFCNPJTexto{""}[0]}, []string{"Cnpj"}},
+		{"dígitos inválidos", documentoTeste{
+			CpfCnpj: ptrDoc("529.982.247-24"), Cnpj: &cnpj, Documento: "1234",
+		}, []string{"CpfCnpj", "Documento"}},
+	}
+
+	for _, tt := range tests {
+		err := Validate(nil, "TESTE", &tt.input)
+
+		var got []string
+		var validationError *erros.ValidationError
+		if errors.As(err, &validationError) {
+			got = validationError.Campos()
+		} else if err != nil {
+			t.Errorf("%s: erro inesperado: %v", tt.name, err)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: campos = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type tamanhosTeste struct {
+	Nome     *string      `json:"Nome" validate:"max=5"`
+	Codigo   *int         `json:"Codigo" validate:"precision=3"`
+	Aliquota *float64     `json:"Aliquota" validate:"precision=5,scale=2"`
+	Valor    *money.Money `json:"Valor" validate:"precision=6,scale=2"`
+}
+
+func TestValidateTamanhos(t *testing.T) {
+	tests := []struct {
+		name  string
+		input tamanhosTeste
+		want  []string
+	}{
+		{"dentro dos limites", tamanhosTeste{
+			Nome: ptrString("ações"), Codigo: ptrInt(999), Aliquota: ptrFloat(-999.99), Valor: ptrMoney(money.New(9999, 99)),
+		}, nil},
+		{"texto longo", tamanhosTeste{Nome: ptrString("Fulano")}, []string{"Nome"}},
+		{"dígitos", tamanhosTeste{Codigo: ptrInt(1000)}, []string{"Codigo"}},
+		{"dígitos negativos", tamanhosTeste{Codigo: ptrInt(-1000)}, []string{"Codigo"}},
+		{"casas decimais", tamanhosTeste{Aliquota: ptrFloat(1.005)}, []string{"Aliquota"}},
+		{"dígitos inteiros", tamanhosTeste{Aliquota: ptrFloat(1000)}, []string{"Aliquota"}},
+		{"money", tamanhosTeste{Valor: ptrMoney(money.New(10000, 0))}, []string{"Valor"}},
+		{"vazia", tamanhosTeste{}, nil},
+	}
+
+	for _, tt := range tests {
+		err := Validate(nil, "TESTE", &tt.input)
+
+		var got []string
+		var validationError *erros.ValidationError
+		if errors.As(err, &validationError) {
+			got = validationError.Campos()
+		} else if err != nil {
+			t.Errorf("%s: erro inesperado: %v", tt.name, err)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: campos = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTruncate(t *testing.T) {
+	nome, aliquota := "Fulano de Tal", 1.005
+	input := &tamanhosTeste{Nome: &nome, Aliquota: &aliquota, Codigo: ptrInt(1000)}
+	sess := &session.Session{TruncateText: true}
+
+	err := Validate(sess, "TESTE", input)
+
+	var validationError *erros.ValidationError
+	if !errors.As(err, &validationError) || !slices.Equal(validationError.Campos(), []string{"Codigo"}) {
+		t.Errorf("erro = %v, esperado apenas Codigo rejeitado", err)
+	}
+
+	output := Truncate(sess, input)
+
+	if *output.Nome != "Fulan" {
+		t.Errorf("Nome = %q, esperado \"Fulan\"", *output.Nome)
+	}
+
+	// 1.005 é guardado como 1.00499..., arredondado para 1.00.
+	if *output.Aliquota != 1 {
+		t.Errorf("Aliquota = %v, esperado 1", *output.Aliquota)
+	}
+
+	// A entrada e as variáveis de quem chamou não são alteradas.
+	if nome != "Fulano de Tal" || aliquota != 1.005 || input.Nome != &nome || input.Aliquota != &aliquota {
+		t.Errorf("entrada alterada: Nome = %q, Aliquota = %v", nome, aliquota)
+	}
+
+	if output.Nome == input.Nome || output.Aliquota == input.Aliquota {
+		t.Error("a cópia compartilha ponteiros com a entrada")
+	}
+
+	if got := Truncate(nil, input); got != input {
+		t.Error("sem TruncateText, Truncate deve retornar a própria entrada")
+	}
+}
+
+type listaTeste struct {
+	Itens []*tamanhosTeste `json:"Itens"`
+}
+
+func TestTruncateAninhado(t *testing.T) {
+	nome := "Fulano de Tal"
+	item := &tamanhosTeste{Nome: &nome}
+	input := &listaTeste{Itens: []*tamanhosTeste{item}}
+
+	output := Truncate(&session.Session{TruncateText: true}, input)
+
+	if got := *output.Itens[0].Nome; got != "Fulan" {
+		t.Errorf("Itens[0].Nome = %q, esperado \"Fulan\"", got)
+	}
+
+	if nome != "Fulano de Tal" || input.Itens[0] != item || item.Nome != &nome {
+		t.Errorf("entrada alterada: Itens[0].Nome = %q", nome)
+	}
+}
+
+func ptrString(s string) *string {
+	return &s
+}
+
+func ptrInt(n int) *int {
+	return &n
+}
+
+func ptrFloat(f float64) *float64 {
+	return &f
+}
+
+func ptrDoc(s string) *doc.CPFCNPJ {
+	c := doc.CPFCNPJ(s)
+	return &c
+}
+
+func ptrMoney(m money.Money) *money.Money {
+	return &m
+}
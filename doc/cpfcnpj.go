@@ -0,0 +1,312 @@
+// Package doc define o tipo dos documentos de pessoas (CPF e CNPJ) enviados e
+// recebidos pelo Imobiliar.
+package doc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/itispx/goimobiliar/enums"
+)
+
+// ErrInvalido é retornado por Parse quando o documento não é um CPF ou CNPJ
+// válido.
+var ErrInvalido = errors.New("doc: CPF/CNPJ inválido")
+
+const (
+	tamanhoCPF  = 11
+	tamanhoCNPJ = 14
+)
+
+// CPFCNPJ é um CPF ou CNPJ, guardado apenas com os dígitos e com os zeros à
+// esquerda. É enviado como número, o formato da maioria das actions; para os
+// campos de texto, use CPFCNPJTexto. Valores com máscara, como
+// CPFCNPJ("012.345.678-90"), são tratados pelos dígitos em todos os métodos.
+type CPFCNPJ string
+
+// Parse lê um CPF ou CNPJ, com ou sem máscara, e verifica os dígitos
+// verificadores.
+func Parse(s string) (CPFCNPJ, error) {
+	digits, ok := onlyDigits(s)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalido, s)
+	}
+
+	c := CPFCNPJ(digits)
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalido, s)
+	}
+
+	return c, nil
+}
+
+// MustParse é como Parse, mas entra em pânico se o documento for inválido.
+func MustParse(s string) CPFCNPJ {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// FromNumber converte o documento recebido como número, recompondo os zeros à
+// esquerda. Números com até 11 dígitos são tratados como CPF quando os dígitos
+// verificadores conferem, senão como CNPJ.
+func FromNumber(n int64) CPFCNPJ {
+	if n <= 0 {
+		return ""
+	}
+
+	return pad(strconv.FormatInt(n, 10))
+}
+
+// IsCPF indica se o documento tem o tamanho de um CPF.
+func (c CPFCNPJ) IsCPF() bool {
+	return len(c.digits()) == tamanhoCPF
+}
+
+// IsCNPJ indica se o documento tem o tamanho de um CNPJ.
+func (c CPFCNPJ) IsCNPJ() bool {
+	return len(c.digits()) == tamanhoCNPJ
+}
+
+// Valid verifica o tamanho e os dígitos verificadores.
+func (c CPFCNPJ) Valid() bool {
+	digits := c.digits()
+
+	switch len(digits) {
+	case tamanhoCPF:
+		return validCPF(digits)
+	case tamanhoCNPJ:
+		return validCNPJ(digits)
+	}
+
+	return false
+}
+
+// TipoPessoa retorna o tipo de pessoa do documento: física para CPF e
+// jurídica para CNPJ.
+func (c CPFCNPJ) TipoPessoa() enums.TipoPessoa {
+	switch {
+	case c.IsCPF():
+		return enums.PessoaFisica
+	case c.IsCNPJ():
+		return enums.PessoaJuridica
+	}
+
+	return enums.PessoaNaoInformada
+}
+
+// Format retorna o documento com máscara: 000.000.000-00 para CPF e
+// 00.000.000/0000-00 para CNPJ. Outros valores são retornados como estão.
+func (c CPFCNPJ) Format() string {
+	s := c.digits()
+
+	switch len(s) {
+	case tamanhoCPF:
+		return s[:3] + "." + s[3:6] + "." + s[6:9] + "-" + s[9:]
+	case tamanhoCNPJ:
+		return s[:2] + "." + s[2:5] + "." + s[5:8] + "/" + s[8:12] + "-" + s[12:]
+	}
+
+	return string(c)
+}
+
+// String retorna o documento com máscara. Veja Format.
+func (c CPFCNPJ) String() string {
+	return c.Format()
+}
+
+// Int64 retorna o documento como número, sem os zeros à esquerda.
+func (c CPFCNPJ) Int64() int64 {
+	n, _ := strconv.ParseInt(c.digits(), 10, 64)
+
+	return n
+}
+
+func (c CPFCNPJ) MarshalJSON() ([]byte, error) {
+	if strings.TrimSpace(string(c)) == "" {
+		return []byte("null"), nil
+	}
+
+	digits, ok := onlyDigits(string(c))
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalido, string(c))
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalido, string(c))
+	}
+
+	return []byte(strconv.FormatInt(n, 10)), nil
+}
+
+// UnmarshalJSON aceita o documento como número ou texto, com ou sem máscara,
+// e textos vazios como o documento vazio. Os dígitos verificadores não são
+// conferidos, para que documentos antigos cadastrados no Imobiliar continuem
+// legíveis; use Valid para verificá-los.
+func (c *CPFCNPJ) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	var s string
+	switch value := value.(type) {
+	case float64:
+		s = strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		s = value
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalido, data)
+	}
+
+	if strings.TrimSpace(s) == "" {
+		*c = ""
+		return nil
+	}
+
+	digits, ok := onlyDigits(s)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalido, data)
+	}
+
+	if _, ok := value.(string); ok && (len(digits) == tamanhoCPF || len(digits) == tamanhoCNPJ) {
+		*c = CPFCNPJ(digits)
+		return nil
+	}
+
+	*c = pad(strings.TrimLeft(digits, "0"))
+
+	return nil
+}
+
+// CPFCNPJTexto é um CPFCNPJ enviado como texto, apenas com os dígitos, para
+// as actions que recebem o documento em um campo String.
+type CPFCNPJTexto CPFCNPJ
+
+func (c CPFCNPJTexto) Valid() bool {
+	return CPFCNPJ(c).Valid()
+}
+
+func (c CPFCNPJTexto) TipoPessoa() enums.TipoPessoa {
+	return CPFCNPJ(c).TipoPessoa()
+}
+
+func (c CPFCNPJTexto) Format() string {
+	return CPFCNPJ(c).Format()
+}
+
+func (c CPFCNPJTexto) String() string {
+	return CPFCNPJ(c).Format()
+}
+
+func (c CPFCNPJTexto) MarshalJSON() ([]byte, error) {
+	if strings.TrimSpace(string(c)) == "" {
+		return json.Marshal("")
+	}
+
+	digits, ok := onlyDigits(string(c))
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalido, string(c))
+	}
+
+	return json.Marshal(digits)
+}
+
+func (c *CPFCNPJTexto) UnmarshalJSON(data []byte) error {
+	return (*CPFCNPJ)(c).UnmarshalJSON(data)
+}
+
+// digits retorna o documento sem a máscara, ou texto vazio se houver
+// caracteres que não são de máscara.
+func (c CPFCNPJ) digits() string {
+	digits, _ := onlyDigits(string(c))
+	return digits
+}
+
+// onlyDigits remove a máscara (pontos, barra, hífen e espaços) e informa se
+// restaram apenas dígitos.
+func onlyDigits(s string) (string, bool) {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.' || r == '/' || r == '-' || r == ' ':
+		default:
+			return "", false
+		}
+	}
+
+	return b.String(), b.Len() > 0
+}
+
+// pad recompõe os zeros à esquerda perdidos quando o documento foi guardado
+// como número.
+func pad(digits string) CPFCNPJ {
+	if digits == "" {
+		return ""
+	}
+
+	if len(digits) <= tamanhoCPF {
+		cpf := strings.Repeat("0", tamanhoCPF-len(digits)) + digits
+		if validCPF(cpf) {
+			return CPFCNPJ(cpf)
+		}
+	}
+
+	if len(digits) <= tamanhoCNPJ {
+		return CPFCNPJ(strings.Repeat("0", tamanhoCNPJ-len(digits)) + digits)
+	}
+
+	return CPFCNPJ(digits)
+}
+
+func validCPF(s string) bool {
+	if repeated(s) {
+		return false
+	}
+
+	return checkDigit(s[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[9] &&
+		checkDigit(s[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[10]
+}
+
+func validCNPJ(s string) bool {
+	if repeated(s) {
+		return false
+	}
+
+	return checkDigit(s[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[12] &&
+		checkDigit(s[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[13]
+}
+
+// checkDigit calcula o dígito verificador (módulo 11) dos dígitos informados.
+func checkDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, weight := range weights {
+		sum += int(digits[i]-'0') * weight
+	}
+
+	if rest := sum % 11; rest >= 2 {
+		return byte('0' + 11 - rest)
+	}
+
+	return '0'
+}
+
+// repeated indica documentos com todos os dígitos iguais, como 000.000.000-00,
+// que passam no cálculo mas não são válidos.
+func repeated(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
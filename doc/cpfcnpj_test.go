@@ -0,0 +1,214 @@
+package doc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/itispx/goimobiliar/enums"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  CPFCNPJ
+		tipo  enums.TipoPessoa
+		valid bool
+	}{
+		{"012.345.678-90", "01234567890", enums.PessoaFisica, true},
+		{"52998224725", "52998224725", enums.PessoaFisica, true},
+		{"529.982.247-24", "", "", false},
+		{"11.222.333/0001-81", "11222333000181", enums.PessoaJuridica, true},
+		{"11444777000161", "11444777000161", enums.PessoaJuridica, true},
+		{"11.444.777/0001-62", "", "", false},
+		{"111.111.111-11", "", "", false},
+		{"00000000000000", "", "", false},
+		{"1234", "", "", false},
+		{"123.456.789-0a", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalido) {
+				t.Errorf("Parse(%q) erro = %v, esperado ErrInvalido", tt.in, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Parse(%q) erro inesperado: %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want || got.TipoPessoa() != tt.tipo {
+			t.Errorf("Parse(%q) = %q (%q), esperado %q (%q)", tt.in, got, got.TipoPessoa(), tt.want, tt.tipo)
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits  string
+		weights []int
+		want    byte
+	}{
+		{"529982247", []int{10, 9, 8, 7, 6, 5, 4, 3, 2}, '2'},
+		{"5299822472", []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}, '5'},
+		{"112223330001", []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, '8'},
+		{"1122233300018", []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, '1'},
+		// Resto menor que 2 resulta em dígito 0.
+		{"012345678", []int{10, 9, 8, 7, 6, 5, 4, 3, 2}, '9'},
+		{"0123456789", []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}, '0'},
+	}
+
+	for _, tt := range tests {
+		if got := checkDigit(tt.digits, tt.weights); got != tt.want {
+			t.Errorf("checkDigit(%q) = %c, esperado %c", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in   CPFCNPJ
+		want string
+	}{
+		{"01234567890", "012.345.678-90"},
+		{"11222333000181", "11.222.333/0001-81"},
+		{"012.345.678-90", "012.345.678-90"},
+		{"012 345 678 90", "012.345.678-90"},
+		{"11.222.333/0001-81", "11.222.333/0001-81"},
+		{"1234", "1234"},
+		{"abc", "abc"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.Format(); got != tt.want {
+			t.Errorf("%q.Format() = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMascara(t *testing.T) {
+	tests := []struct {
+		in     CPFCNPJ
+		cpf    bool
+		cnpj   bool
+		tipo   enums.TipoPessoa
+		valid  bool
+		number int64
+	}{
+		{"012.345.678-90", true, false, enums.PessoaFisica, true, 1234567890},
+		{"529.982.247-24", true, false, enums.PessoaFisica, false, 52998224724},
+		{"11.222.333/0001-81", false, true, enums.PessoaJuridica, true, 11222333000181},
+		{"11.444.777/0001-62", false, true, enums.PessoaJuridica, false, 11444777000162},
+		{"123.456", false, false, enums.PessoaNaoInformada, false, 123456},
+		{"012.345.678-9a", false, false, enums.PessoaNaoInformada, false, 0},
+	}
+
+	for _, tt := range tests {
+		if tt.in.IsCPF() != tt.cpf || tt.in.IsCNPJ() != tt.cnpj || tt.in.TipoPessoa() != tt.tipo {
+			t.Errorf("%q: IsCPF = %v, IsCNPJ = %v, TipoPessoa = %q", tt.in, tt.in.IsCPF(), tt.in.IsCNPJ(), tt.in.TipoPessoa())
+		}
+
+		if got := tt.in.Valid(); got != tt.valid {
+			t.Errorf("%q.Valid() = %v, esperado %v", tt.in, got, tt.valid)
+		}
+
+		if got := CPFCNPJTexto(tt.in).Valid(); got != tt.valid {
+			t.Errorf("CPFCNPJTexto(%q).Valid() = %v, esperado %v", tt.in, got, tt.valid)
+		}
+
+		if got := tt.in.Int64(); got != tt.number {
+			t.Errorf("%q.Int64() = %d, esperado %d", tt.in, got, tt.number)
+		}
+	}
+}
+
+func TestFromNumber(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want CPFCNPJ
+	}{
+		{1234567890, "01234567890"},
+		{11222333000181, "11222333000181"},
+		// CNPJ com zeros à esquerda que não formam um CPF válido.
+		{191000100, "00000191000100"},
+		{0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := FromNumber(tt.in); got != tt.want {
+			t.Errorf("FromNumber(%d) = %q, esperado %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{CPFCNPJ("01234567890"), `1234567890`},
+		{CPFCNPJ("012.345.678-90"), `1234567890`},
+		{CPFCNPJ(""), `null`},
+		{CPFCNPJ("  "), `null`},
+		{CPFCNPJTexto("11.222.333/0001-81"), `"11222333000181"`},
+		{CPFCNPJTexto(""), `""`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Errorf("Marshal(%q) erro inesperado: %v", tt.in, err)
+			continue
+		}
+
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%q) = %s, esperado %s", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := json.Marshal(CPFCNPJ("abc")); !errors.Is(err, ErrInvalido) {
+		t.Errorf("Marshal(\"abc\") erro = %v, esperado ErrInvalido", err)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    CPFCNPJ
+		wantErr bool
+	}{
+		{`1234567890`, "01234567890", false},
+		{`11222333000181`, "11222333000181", false},
+		{`"012.345.678-90"`, "01234567890", false},
+		{`"00191000100"`, "00191000100", false},
+		{`""`, "", false},
+		{`"   "`, "", false},
+		{`null`, "", false},
+		{`"abc"`, "", true},
+		{`true`, "", true},
+	}
+
+	for _, tt := range tests {
+		var got CPFCNPJ
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) erro = %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, esperado %q", tt.in, got, tt.want)
+		}
+
+		var texto CPFCNPJTexto
+		if err := json.Unmarshal([]byte(tt.in), &texto); (err != nil) != tt.wantErr || CPFCNPJ(texto) != tt.want {
+			t.Errorf("Unmarshal(%s) como texto = %q, %v", tt.in, texto, err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package enums
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// TipoPessoa é o tipo de pessoa: física ou jurídica.
+type TipoPessoa string
+
+const (
+	PessoaFisica       TipoPessoa = "F"
+	PessoaJuridica     TipoPessoa = "J"
+	PessoaNaoInformada TipoPessoa = " " // Nas pesquisas, seleciona todas.
+)
+
+func (t TipoPessoa) Valid() bool {
+	return slices.Contains([]TipoPessoa{PessoaFisica, PessoaJuridica, PessoaNaoInformada}, t)
+}
+
+func (t TipoPessoa) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+func (t *TipoPessoa) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(t))
+}
+
+// PrevisaoReal indica se o lançamento é previsto ou real.
+type PrevisaoReal string
+
+const (
+	Previsto       PrevisaoReal = "P"
+	Real           PrevisaoReal = "R"
+	PrevistoOuReal PrevisaoReal = "T" // Apenas nas pesquisas.
+)
+
+func (p PrevisaoReal) Valid() bool {
+	return slices.Contains([]PrevisaoReal{Previsto, Real, PrevistoOuReal}, p)
+}
+
+func (p PrevisaoReal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(p))
+}
+
+func (p *PrevisaoReal) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(p))
+}
+
+// Frequencia indica se o lançamento é único ou permanente.
+type Frequencia string
+
+const (
+	Unico      Frequencia = "U"
+	Permanente Frequencia = "P"
+)
+
+func (f Frequencia) Valid() bool {
+	return slices.Contains([]Frequencia{Unico, Permanente}, f)
+}
+
+func (f Frequencia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(f))
+}
+
+func (f *Frequencia) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(f))
+}
+
+// DebitoCredito indica se o lançamento é de débito ou de crédito.
+type DebitoCredito string
+
+const (
+	Debito  DebitoCredito = "D"
+	Credito DebitoCredito = "C"
+)
+
+func (d DebitoCredito) Valid() bool {
+	return slices.Contains([]DebitoCredito{Debito, Credito}, d)
+}
+
+func (d DebitoCredito) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(d))
+}
+
+func (d *DebitoCredito) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(d))
+}
+
+// OrigemCobranca é a origem da cobrança de um boleto: locação ou condomínio.
+type OrigemCobranca string
+
+const (
+	CobrancaLocacao    OrigemCobranca = "L"
+	CobrancaCondominio OrigemCobranca = "C"
+)
+
+func (o OrigemCobranca) Valid() bool {
+	return slices.Contains([]OrigemCobranca{CobrancaLocacao, CobrancaCondominio}, o)
+}
+
+func (o OrigemCobranca) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(o))
+}
+
+func (o *OrigemCobranca) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(o))
+}
+
+// FormaPagamento é o código da forma de pagamento de um lançamento. Os
+// códigos são cadastrados no Imobiliar e podem ser listados com
+// TABELA_CONSULTAR, por isso apenas o formato é verificado: um caractere,
+// letra maiúscula ou dígito.
+type FormaPagamento string
+
+func (f FormaPagamento) Valid() bool {
+	return validCode(string(f))
+}
+
+func (f FormaPagamento) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(f))
+}
+
+func (f *FormaPagamento) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(f))
+}
+
+// TipoDocumento é o código do tipo de documento de um lançamento de contas a
+// pagar. Assim como FormaPagamento, apenas o formato é verificado.
+type TipoDocumento string
+
+const (
+	DocumentoNotaFiscal TipoDocumento = "N"
+	DocumentoExtra      TipoDocumento = "E"
+)
+
+func (t TipoDocumento) Valid() bool {
+	return validCode(string(t))
+}
+
+func (t TipoDocumento) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+func (t *TipoDocumento) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, (*string)(t))
+}
+
+func validCode(code string) bool {
+	return len(code) == 1 && (code[0] >= 'A' && code[0] <= 'Z' || code[0] >= '0' && code[0] <= '9')
+}
+
+func unmarshalCode(data []byte, code *string) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(data, code)
+}
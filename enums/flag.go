@@ -0,0 +1,68 @@
+// Package enums define os tipos dos campos codificados do Imobiliar: os
+// indicadores 'S'/'N' (Flag) e os códigos de um caractere, como TipoPessoa e
+// DebitoCredito.
+//
+// Os códigos têm o método Valid, usado por consts.Validate para rejeitar em Run
+// os valores fora do domínio, antes que cheguem ao webservice. Na leitura e na
+// escrita em JSON, os códigos são mantidos como vieram e os indicadores
+// diferentes de 'S' são lidos como Nao, para que respostas com valores novos
+// continuem legíveis.
+package enums
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Flag é um indicador 'S'/'N'. É enviado como "S" ou "N".
+type Flag bool
+
+const (
+	Sim Flag = true
+	Nao Flag = false
+)
+
+// NewFlag retorna um ponteiro para o indicador, para os campos opcionais.
+func NewFlag(value bool) *Flag {
+	f := Flag(value)
+
+	return &f
+}
+
+// String retorna "S" ou "N".
+func (f Flag) String() string {
+	if f {
+		return "S"
+	}
+
+	return "N"
+}
+
+func (f Flag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON aceita "S" e "N" em maiúsculas ou minúsculas e booleanos.
+// Como os códigos, o indicador é lido sem erro: qualquer outro valor, como
+// texto vazio, é lido como Nao.
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case bool:
+		*f = Flag(value)
+	case string:
+		*f = Flag(strings.EqualFold(strings.TrimSpace(value), "S"))
+	default:
+		*f = Nao
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package enums
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlagJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Flag
+	}{
+		{`"S"`, Sim},
+		{`"s"`, Sim},
+		{`" S "`, Sim},
+		{`true`, Sim},
+		{`"N"`, Nao},
+		{`""`, Nao},
+		{`false`, Nao},
+		{`"X"`, Nao},
+		{`1`, Nao},
+	}
+
+	for _, tt := range tests {
+		got := Sim
+		if tt.want == Sim {
+			got = Nao
+		}
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, %v, esperado %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for flag, want := range map[Flag]string{Sim: `"S"`, Nao: `"N"`} {
+		if data, err := json.Marshal(flag); err != nil || string(data) != want {
+			t.Errorf("Marshal(%v) = %s, %v, esperado %s", bool(flag), data, err, want)
+		}
+	}
+}
+
+func TestCodeValid(t *testing.T) {
+	tests := []struct {
+		code interface{ Valid() bool }
+		want bool
+	}{
+		{PessoaFisica, true},
+		{PessoaNaoInformada, true},
+		{TipoPessoa("s"), false},
+		{Debito, true},
+		{DebitoCredito("X"), false},
+		{TipoDocumento("Z"), true},
+		{TipoDocumento("ZZ"), false},
+		{FormaPagamento("1"), true},
+		{FormaPagamento(""), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.Valid(); got != tt.want {
+			t.Errorf("%#v.Valid() = %v, esperado %v", tt.code, got, tt.want)
+		}
+	}
+}
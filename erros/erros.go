@@ -3,6 +3,8 @@ package erros
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 )
 
 type RequestResponse struct {
@@ -29,31 +31,144 @@ type Erro struct {
 
 func CheckResponseError(body *[]byte) error {
 	if string(*body) == "468 - session expired, new login required" {
-		return errors.New("imobiliar: sessão inválida")
+		return fmt.Errorf("imobiliar: %w", ErrSessaoInvalida)
 	}
 
 	var response map[string]map[string]interface{}
 	if err := json.Unmarshal(*body, &response); err != nil {
-		return err
+		return RespostaInvalida(err)
 	}
 
 	hasError, ok := response["Header"]["Error"].(bool)
 	if !ok {
-		return errors.New("failed type assertion on 'Header.Error'")
+		return RespostaInvalida(errors.New("failed type assertion on 'Header.Error'"))
 	}
 
 	if hasError {
 		var responseBody RequestResponse
 		if err := json.Unmarshal(*body, &responseBody); err != nil {
-			return err
+			return RespostaInvalida(err)
 		}
 
-		if len(responseBody.Body.Erros) <= 0 {
-			return errors.New("imobiliar: erro lançado, mas nenhum encontrado")
+		apiError := APIError{
+			Action:    responseBody.Header.Action,
+			ErrorCode: responseBody.Header.ErrorCode,
+			SessionId: responseBody.Header.SessionID,
+			Erros:     make([]Erro, 0, len(responseBody.Body.Erros)),
 		}
 
-		return errors.New(responseBody.Body.Erros[0].Mensagem)
+		for _, erro := range responseBody.Body.Erros {
+			if erro != nil {
+				apiError.Erros = append(apiError.Erros, *erro)
+			}
+		}
+
+		return &apiError
+	}
+
+	return nil
+}
+
+// APIError é o erro retornado pelo webservice quando Header.Error é verdadeiro.
+// Use errors.As para obter os campos rejeitados.
+type APIError struct {
+	Action    string `json:"action,omitempty"`
+	ErrorCode int    `json:"errorCode,omitempty"`
+	SessionId string `json:"sessionId,omitempty"`
+	Erros     []Erro `json:"erros,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if len(e.Erros) <= 0 {
+		return "imobiliar: erro lançado, mas nenhum encontrado"
+	}
+
+	mensagens := make([]string, 0, len(e.Erros))
+	for _, erro := range e.Erros {
+		mensagens = append(mensagens, erro.Mensagem)
+	}
+
+	return strings.Join(mensagens, "; ")
+}
+
+// Unwrap classifica o erro para uso com errors.Is: ErrCredenciais quando o
+// LOGIN rejeita o usuário ou a senha, ErrNaoEncontrado quando a mensagem
+// indica registro inexistente e ErrValidacao quando algum campo foi
+// rejeitado. Outras falhas no LOGIN, como o limite de sessões, não são
+// classificadas.
+func (e *APIError) Unwrap() error {
+	if e.Action == "LOGIN" && e.credenciais() {
+		return ErrCredenciais
+	}
+
+	for _, erro := range e.Erros {
+		mensagem := strings.ToLower(erro.Mensagem)
+		for _, trecho := range naoEncontradoTrechos {
+			if strings.Contains(mensagem, trecho) {
+				return ErrNaoEncontrado
+			}
+		}
+	}
+
+	if len(e.Campos()) > 0 {
+		return ErrValidacao
 	}
 
 	return nil
 }
+
+// credenciais indica se algum erro rejeita o usuário ou a senha.
+func (e *APIError) credenciais() bool {
+	for _, erro := range e.Erros {
+		campo := strings.ToUpper(erro.Campo)
+		if campo == "USER_ID" || campo == "USER_PASS" {
+			return true
+		}
+
+		mensagem := strings.ToLower(erro.Mensagem)
+		if !strings.Contains(mensagem, "usuário") && !strings.Contains(mensagem, "usuario") &&
+			!strings.Contains(mensagem, "senha") {
+			continue
+		}
+
+		for _, trecho := range credenciaisTrechos {
+			if strings.Contains(mensagem, trecho) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var credenciaisTrechos = []string{
+	"inválid",
+	"invalid",
+	"incorret",
+	"não confere",
+	"nao confere",
+	"não encontrad",
+	"nao encontrad",
+	"bloquead",
+	"expirad",
+}
+
+var naoEncontradoTrechos = []string{
+	"não encontrad",
+	"nao encontrad",
+	"não existe",
+	"nao existe",
+	"inexistente",
+}
+
+// Campos retorna os nomes dos campos rejeitados pelo webservice.
+func (e *APIError) Campos() []string {
+	campos := make([]string, 0, len(e.Erros))
+	for _, erro := range e.Erros {
+		if erro.Campo != "" {
+			campos = append(campos, erro.Campo)
+		}
+	}
+
+	return campos
+}
@@ -0,0 +1,33 @@
+package erros
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorUnwrap(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want error
+	}{
+		{"senha inválida", &APIError{Action: "LOGIN", Erros: []Erro{{Mensagem: "Usuário ou senha inválidos"}}}, ErrCredenciais},
+		{"senha incorreta", &APIError{Action: "LOGIN", Erros: []Erro{{Mensagem: "Senha incorreta"}}}, ErrCredenciais},
+		{"campo USER_PASS", &APIError{Action: "LOGIN", Erros: []Erro{{Campo: "USER_PASS", Mensagem: "Valor rejeitado"}}}, ErrCredenciais},
+		{"limite de sessões", &APIError{Action: "LOGIN", Erros: []Erro{{Mensagem: "Limite de sessões simultâneas atingido"}}}, nil},
+		{"senha fora do LOGIN", &APIError{Action: "CADASTRO_PESSOA_ALTERAR", Erros: []Erro{{Mensagem: "Senha inválida"}}}, nil},
+		{"não encontrado", &APIError{Action: "CADASTRO_PESSOA_CONSULTAR", Erros: []Erro{{Mensagem: "Pessoa não encontrada"}}}, ErrNaoEncontrado},
+		{"campo rejeitado", &APIError{Action: "CTAREC_BOLETO_QUITAR", Erros: []Erro{{Campo: "VlrPagamento", Mensagem: "Valor inválido"}}}, ErrValidacao},
+	}
+
+	for _, tt := range tests {
+		got := tt.err.Unwrap()
+		if got != tt.want {
+			t.Errorf("%s: Unwrap = %v, esperado %v", tt.name, got, tt.want)
+		}
+
+		if tt.want != ErrCredenciais && errors.Is(tt.err, ErrCredenciais) {
+			t.Errorf("%s: classificado como ErrCredenciais", tt.name)
+		}
+	}
+}
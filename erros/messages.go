@@ -3,13 +3,80 @@ package erros
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
-	ErrBaseInvalida   = errors.New("base inválida")
-	ErrSessaoInvalida = errors.New("sessão inválida")
+	ErrBaseInvalida     = errors.New("base inválida")
+	ErrSessaoInvalida   = errors.New("sessão inválida")
+	ErrCredenciais      = errors.New("credenciais inválidas")
+	ErrValidacao        = errors.New("dados inválidos")
+	ErrNaoEncontrado    = errors.New("registro não encontrado")
+	ErrTransiente       = errors.New("falha temporária de comunicação")
+	ErrRespostaInvalida = errors.New("resposta inválida")
+	ErrCircuitoAberto   = errors.New("circuito aberto")
 )
 
 func ErrCampoVazio(f string) error {
 	return fmt.Errorf("campo '%s' vazio", f)
 }
+
+// RespostaInvalida classifica err como ErrRespostaInvalida, mantendo o erro original.
+func RespostaInvalida(err error) error {
+	return fmt.Errorf("imobiliar: %w: %w", ErrRespostaInvalida, err)
+}
+
+// Transiente classifica err como ErrTransiente, mantendo o erro original.
+func Transiente(err error) error {
+	return fmt.Errorf("imobiliar: %w: %w", ErrTransiente, err)
+}
+
+// HTTPError é retornado quando o webservice responde com um status HTTP de falha.
+// Status 5xx e 429 são classificados como ErrTransiente.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("imobiliar: status HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *HTTPError) Unwrap() error {
+	if e.StatusCode >= 500 || e.StatusCode == 429 {
+		return ErrTransiente
+	}
+
+	return ErrRespostaInvalida
+}
+
+// ValidationError é retornado antes do envio quando a entrada da action não
+// passa na validação local. Erros lista todos os campos rejeitados, com o
+// caminho completo para campos de listas, como "Parcelas[0].Valor".
+type ValidationError struct {
+	Action string
+	Erros  []Erro
+}
+
+func (e *ValidationError) Error() string {
+	mensagens := make([]string, 0, len(e.Erros))
+	for _, erro := range e.Erros {
+		mensagens = append(mensagens, erro.Campo+": "+erro.Mensagem)
+	}
+
+	return fmt.Sprintf("imobiliar: %s: %s", e.Action, strings.Join(mensagens, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidacao
+}
+
+// Campos retorna os nomes dos campos rejeitados.
+func (e *ValidationError) Campos() []string {
+	campos := make([]string, 0, len(e.Erros))
+	for _, erro := range e.Erros {
+		campos = append(campos, erro.Campo)
+	}
+
+	return campos
+}
@@ -0,0 +1,207 @@
+package imobdate
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LayoutCompetencia é o formato das competências enviadas ao webservice.
+const LayoutCompetencia = "200601"
+
+var competenciaLayouts = []string{
+	LayoutCompetencia,
+	"01/2006",
+	"1/2006",
+	"2006-01",
+	"2006/01",
+	"01-2006",
+}
+
+// Competencia é o mês de referência de lançamentos, boletos e relatórios. É
+// enviada como texto no formato YYYYMM; para os campos numéricos, use
+// CompetenciaNumero. O valor zero representa a competência não informada.
+// Valores Competencia podem ser comparados com ==.
+type Competencia struct {
+	t time.Time // Primeiro dia do mês, em UTC.
+}
+
+// NewCompetencia retorna a competência do mês informado. Meses fora do
+// intervalo são normalizados: NewCompetencia(2024, 13) é 202501.
+func NewCompetencia(year int, month time.Month) Competencia {
+	return Competencia{t: time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// CompetenciaOf retorna a competência de t no fuso de t.
+func CompetenciaOf(t time.Time) Competencia {
+	return NewCompetencia(t.Year(), t.Month())
+}
+
+// ParseCompetencia lê uma competência nos formatos YYYYMM, MM/YYYY, YYYY-MM
+// ou como uma data completa, da qual é usado o mês.
+func ParseCompetencia(s string) (Competencia, error) {
+	s = strings.TrimSpace(s)
+	if blank(s) {
+		return Competencia{}, nil
+	}
+
+	for _, layout := range competenciaLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return CompetenciaOf(t), nil
+		}
+	}
+
+	if date, err := ParseDate(s); err == nil {
+		return date.Competencia(), nil
+	}
+
+	return Competencia{}, fmt.Errorf("imobdate: competência inválida: %q", s)
+}
+
+func (c Competencia) Year() int {
+	return c.t.Year()
+}
+
+func (c Competencia) Month() time.Month {
+	return c.t.Month()
+}
+
+// IsZero indica a competência não informada.
+func (c Competencia) IsZero() bool {
+	return c.t.IsZero()
+}
+
+// First retorna o primeiro dia da competência.
+func (c Competencia) First() Date {
+	return Date{t: c.t}
+}
+
+// Last retorna o último dia da competência.
+func (c Competencia) Last() Date {
+	return Date{t: c.t.AddDate(0, 1, -1)}
+}
+
+// Time retorna o início do primeiro dia da competência no fuso informado.
+func (c Competencia) Time(loc *time.Location) time.Time {
+	return c.First().Time(loc)
+}
+
+// AddMonths retorna a competência n meses depois (ou antes, se n for
+// negativo).
+func (c Competencia) AddMonths(n int) Competencia {
+	return NewCompetencia(c.Year(), c.Month()+time.Month(n))
+}
+
+// Next retorna a competência seguinte.
+func (c Competencia) Next() Competencia {
+	return c.AddMonths(1)
+}
+
+// Prev retorna a competência anterior.
+func (c Competencia) Prev() Competencia {
+	return c.AddMonths(-1)
+}
+
+// MonthsUntil retorna o número de meses de c até other, negativo se other for
+// anterior a c.
+func (c Competencia) MonthsUntil(other Competencia) int {
+	return (other.Year()-c.Year())*12 + int(other.Month()-c.Month())
+}
+
+func (c Competencia) Before(other Competencia) bool {
+	return c.t.Before(other.t)
+}
+
+func (c Competencia) After(other Competencia) bool {
+	return c.t.After(other.t)
+}
+
+// Compare retorna -1, 0 ou +1 se c for anterior, igual ou posterior a other.
+func (c Competencia) Compare(other Competencia) int {
+	return c.t.Compare(other.t)
+}
+
+// Contains indica se a data pertence à competência.
+func (c Competencia) Contains(date Date) bool {
+	return date.Competencia() == c
+}
+
+// Range percorre as competências de from até to, inclusive. Se to for
+// anterior a from, o intervalo é percorrido em ordem decrescente.
+func Range(from, to Competencia) iter.Seq[Competencia] {
+	return func(yield func(Competencia) bool) {
+		step := 1
+		if to.Before(from) {
+			step = -1
+		}
+
+		for c := from; ; c = c.AddMonths(step) {
+			if !yield(c) || c == to {
+				return
+			}
+		}
+	}
+}
+
+// String retorna a competência no formato YYYYMM, ou texto vazio para a
+// competência zero.
+func (c Competencia) String() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	return c.t.Format(LayoutCompetencia)
+}
+
+// Int retorna a competência como o número YYYYMM, ou 0 para a competência
+// zero.
+func (c Competencia) Int() int {
+	if c.IsZero() {
+		return 0
+	}
+
+	return c.Year()*100 + int(c.Month())
+}
+
+func (c Competencia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Competencia) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalText(data)
+	if !ok || err != nil {
+		return err
+	}
+
+	competencia, err := ParseCompetencia(s)
+	if err != nil {
+		return err
+	}
+
+	*c = competencia
+
+	return nil
+}
+
+// CompetenciaNumero é uma Competencia enviada como o número YYYYMM, para as
+// actions que recebem a competência em um campo Number.
+type CompetenciaNumero Competencia
+
+func (c CompetenciaNumero) String() string {
+	return Competencia(c).String()
+}
+
+func (c CompetenciaNumero) MarshalJSON() ([]byte, error) {
+	if Competencia(c).IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Itoa(Competencia(c).Int())), nil
+}
+
+func (c *CompetenciaNumero) UnmarshalJSON(data []byte) error {
+	return (*Competencia)(c).UnmarshalJSON(data)
+}
@@ -0,0 +1,169 @@
+package imobdate
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseCompetencia(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Competencia
+		wantErr bool
+	}{
+		{"202401", NewCompetencia(2024, time.January), false},
+		{"12/2023", NewCompetencia(2023, time.December), false},
+		{"3/2024", NewCompetencia(2024, time.March), false},
+		{"2024-02", NewCompetencia(2024, time.February), false},
+		{"2024/11", NewCompetencia(2024, time.November), false},
+		{"05-2024", NewCompetencia(2024, time.May), false},
+		{"15/08/2024", NewCompetencia(2024, time.August), false},
+		{"2024-08-15T10:00:00", NewCompetencia(2024, time.August), false},
+		{" 202406 ", NewCompetencia(2024, time.June), false},
+		{"", Competencia{}, false},
+		{"0", Competencia{}, false},
+		{"00/00/0000", Competencia{}, false},
+		{"202413", Competencia{}, true},
+		{"junho", Competencia{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCompetencia(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCompetencia(%q) erro = %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseCompetencia(%q) = %s, esperado %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCompetenciaAritmetica(t *testing.T) {
+	jan := NewCompetencia(2024, time.January)
+
+	tests := []struct {
+		name string
+		got  Competencia
+		want string
+	}{
+		{"AddMonths(0)", jan.AddMonths(0), "202401"},
+		{"AddMonths(1)", jan.AddMonths(1), "202402"},
+		{"AddMonths(12)", jan.AddMonths(12), "202501"},
+		{"AddMonths(-1)", jan.AddMonths(-1), "202312"},
+		{"AddMonths(-25)", jan.AddMonths(-25), "202112"},
+		{"Next", NewCompetencia(2024, time.December).Next(), "202501"},
+		{"Prev", jan.Prev(), "202312"},
+		{"NewCompetencia(2024, 13)", NewCompetencia(2024, 13), "202501"},
+		{"NewCompetencia(2024, 0)", NewCompetencia(2024, 0), "202312"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.got.String(); got != tt.want {
+			t.Errorf("%s = %s, esperado %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompetenciaMonthsUntil(t *testing.T) {
+	tests := []struct {
+		from, to Competencia
+		want     int
+	}{
+		{NewCompetencia(2024, time.January), NewCompetencia(2024, time.January), 0},
+		{NewCompetencia(2024, time.January), NewCompetencia(2024, time.March), 2},
+		{NewCompetencia(2023, time.November), NewCompetencia(2024, time.February), 3},
+		{NewCompetencia(2024, time.February), NewCompetencia(2023, time.November), -3},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.MonthsUntil(tt.to); got != tt.want {
+			t.Errorf("%s.MonthsUntil(%s) = %d, esperado %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCompetenciaFirstLast(t *testing.T) {
+	tests := []struct {
+		in          Competencia
+		first, last string
+	}{
+		{NewCompetencia(2024, time.February), "01/02/2024", "29/02/2024"},
+		{NewCompetencia(2023, time.February), "01/02/2023", "28/02/2023"},
+		{NewCompetencia(2024, time.December), "01/12/2024", "31/12/2024"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.First().String(); got != tt.first {
+			t.Errorf("%s.First() = %s, esperado %s", tt.in, got, tt.first)
+		}
+
+		if got := tt.in.Last().String(); got != tt.last {
+			t.Errorf("%s.Last() = %s, esperado %s", tt.in, got, tt.last)
+		}
+
+		if !tt.in.Contains(tt.in.Last()) || tt.in.Contains(tt.in.Last().AddDays(1)) {
+			t.Errorf("%s.Contains não respeita o último dia", tt.in)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		from, to Competencia
+		want     []string
+	}{
+		{
+			NewCompetencia(2023, time.November), NewCompetencia(2024, time.February),
+			[]string{"202311", "202312", "202401", "202402"},
+		},
+		{
+			NewCompetencia(2024, time.February), NewCompetencia(2023, time.December),
+			[]string{"202402", "202401", "202312"},
+		},
+		{
+			NewCompetencia(2024, time.May), NewCompetencia(2024, time.May),
+			[]string{"202405"},
+		},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for c := range Range(tt.from, tt.to) {
+			got = append(got, c.String())
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%s, %s) = %v, esperado %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCompetenciaJSON(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{NewCompetencia(2024, time.March), `"202403"`},
+		{Competencia{}, `""`},
+		{CompetenciaNumero(NewCompetencia(2024, time.March)), `202403`},
+		{CompetenciaNumero{}, `null`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("Marshal(%v) = %s, %v, esperado %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{`"202403"`, `202403`, `"03/2024"`} {
+		var got CompetenciaNumero
+		if err := json.Unmarshal([]byte(in), &got); err != nil || Competencia(got) != NewCompetencia(2024, time.March) {
+			t.Errorf("Unmarshal(%s) = %s, %v", in, got, err)
+		}
+	}
+}
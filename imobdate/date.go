@@ -0,0 +1,200 @@
+// Package imobdate define os tipos de data do Imobiliar: Date (DD/MM/YYYY),
+// DateTime (DD/MM/YYYY hh:mm:ss) e Competencia (YYYYMM).
+//
+// Os valores são datas de calendário, sem fuso horário: a data 31/01/2024
+// continua sendo 31/01/2024 em qualquer fuso. A conversão para time.Time
+// recebe o fuso desejado. Na leitura, são aceitos todos os formatos que o
+// webservice retorna, como DD/MM/YYYY, YYYY-MM-DD e YYYY-MM-DDThh:mm:ss.
+package imobdate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LayoutDate é o formato das datas enviadas ao webservice.
+const LayoutDate = "02/01/2006"
+
+// Layouts aceitos na leitura de datas. Os que têm hora são usados também por
+// DateTime.
+var dateLayouts = []string{
+	LayoutDate,
+	"2006-01-02",
+	"20060102",
+	"02/01/06",
+}
+
+var dateTimeLayouts = []string{
+	LayoutDateTime,
+	"02/01/2006 15:04",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02-15-04-05",
+}
+
+// Date é uma data de calendário. O valor zero representa a data não
+// informada e é enviado como texto vazio. Valores Date podem ser comparados
+// com ==.
+type Date struct {
+	t time.Time // Meia-noite em UTC.
+}
+
+// NewDate retorna a data informada. Valores fora do intervalo são
+// normalizados como em time.Date: NewDate(2024, 2, 30) é 01/03/2024.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf retorna a data de t no fuso de t.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// Today retorna a data atual no fuso informado.
+func Today(loc *time.Location) Date {
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseDate lê uma data em qualquer dos formatos aceitos. Textos vazios e
+// datas zeradas, como 00/00/0000, retornam a data zero.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if blank(s) {
+		return Date{}, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+
+	return Date{}, fmt.Errorf("imobdate: data inválida: %q", s)
+}
+
+func (d Date) Year() int {
+	return d.t.Year()
+}
+
+func (d Date) Month() time.Month {
+	return d.t.Month()
+}
+
+func (d Date) Day() int {
+	return d.t.Day()
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.t.Weekday()
+}
+
+// IsZero indica a data não informada.
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Time retorna o início do dia no fuso informado.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// AddDays retorna a data n dias depois (ou antes, se n for negativo).
+func (d Date) AddDays(n int) Date {
+	return Date{t: d.t.AddDate(0, 0, n)}
+}
+
+// AddMonths retorna a data n meses depois, com o dia limitado ao último dia
+// do mês: 31/01/2024 mais um mês é 29/02/2024.
+func (d Date) AddMonths(n int) Date {
+	first := NewDate(d.Year(), d.Month()+time.Month(n), 1)
+	last := first.Competencia().Last().Day()
+
+	return NewDate(first.Year(), first.Month(), min(d.Day(), last))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.t.Before(other.t)
+}
+
+func (d Date) After(other Date) bool {
+	return d.t.After(other.t)
+}
+
+// Compare retorna -1, 0 ou +1 se d for anterior, igual ou posterior a other.
+func (d Date) Compare(other Date) int {
+	return d.t.Compare(other.t)
+}
+
+// Competencia retorna a competência (mês) da data.
+func (d Date) Competencia() Competencia {
+	return NewCompetencia(d.Year(), d.Month())
+}
+
+// String retorna a data no formato DD/MM/YYYY, ou texto vazio para a data
+// zero.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.t.Format(LayoutDate)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalText(data)
+	if !ok || err != nil {
+		return err
+	}
+
+	date, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = date
+
+	return nil
+}
+
+// unmarshalText lê um valor JSON de texto ou número. ok é falso para null.
+func unmarshalText(data []byte) (s string, ok bool, err error) {
+	if string(data) == "null" {
+		return "", false, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", false, err
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, true, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true, nil
+	}
+
+	return "", false, fmt.Errorf("imobdate: valor inválido: %s", data)
+}
+
+// blank indica textos sem data, como "", "0", "00/00/0000" e "  /  /    ".
+func blank(s string) bool {
+	return strings.Trim(s, " 0/-:T") == ""
+}
@@ -0,0 +1,105 @@
+package imobdate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Date
+		wantErr bool
+	}{
+		{"31/01/2024", NewDate(2024, time.January, 31), false},
+		{"2024-01-31", NewDate(2024, time.January, 31), false},
+		{"20240131", NewDate(2024, time.January, 31), false},
+		{"31/01/24", NewDate(2024, time.January, 31), false},
+		{"31/01/2024 23:59:59", NewDate(2024, time.January, 31), false},
+		{"2024-01-31T23:59:59-03:00", NewDate(2024, time.January, 31), false},
+		{"", Date{}, false},
+		{"00/00/0000", Date{}, false},
+		{"  /  /    ", Date{}, false},
+		{"31/02/2024", Date{}, true},
+		{"amanhã", Date{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDate(%q) erro = %v", tt.in, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseDate(%q) = %s, esperado %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDateAddMonths(t *testing.T) {
+	tests := []struct {
+		in   Date
+		n    int
+		want string
+	}{
+		{NewDate(2024, time.January, 31), 1, "29/02/2024"},
+		{NewDate(2023, time.January, 31), 1, "28/02/2023"},
+		{NewDate(2024, time.March, 31), -1, "29/02/2024"},
+		{NewDate(2024, time.January, 15), 12, "15/01/2025"},
+		{NewDate(2024, time.December, 31), 2, "28/02/2025"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.AddMonths(tt.n).String(); got != tt.want {
+			t.Errorf("%s.AddMonths(%d) = %s, esperado %s", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDateTimeOf(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	tm := time.Date(2024, time.January, 31, 22, 30, 15, 500, loc)
+
+	if got := DateOf(tm).String(); got != "31/01/2024" {
+		t.Errorf("DateOf = %s, esperado 31/01/2024", got)
+	}
+
+	if got := DateTimeOf(tm).String(); got != "31/01/2024 22:30:15" {
+		t.Errorf("DateTimeOf = %s, esperado 31/01/2024 22:30:15", got)
+	}
+
+	if got := NewDate(2024, time.January, 31).Time(loc); !got.Equal(time.Date(2024, time.January, 31, 0, 0, 0, 0, loc)) {
+		t.Errorf("Time = %s", got)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Date
+	}{
+		{`"31/01/2024"`, NewDate(2024, time.January, 31)},
+		{`"2024-01-31T00:00:00"`, NewDate(2024, time.January, 31)},
+		{`20240131`, NewDate(2024, time.January, 31)},
+		{`""`, Date{}},
+		{`null`, Date{}},
+	}
+
+	for _, tt := range tests {
+		var got Date
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, %v, esperado %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Data     Date
+		DataHora DateTime
+		Vazia    Date
+	}{NewDate(2024, time.January, 31), NewDateTime(2024, time.January, 31, 8, 5, 0), Date{}})
+	if want := `{"Data":"31/01/2024","DataHora":"31/01/2024 08:05:00","Vazia":""}`; err != nil || string(data) != want {
+		t.Errorf("Marshal = %s, %v, esperado %s", data, err, want)
+	}
+}
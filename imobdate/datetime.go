@@ -0,0 +1,125 @@
+package imobdate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LayoutDateTime é o formato das datas com hora enviadas ao webservice.
+const LayoutDateTime = "02/01/2006 15:04:05"
+
+// DateTime é uma data com hora, sem fuso horário. O valor zero representa a
+// data não informada. Valores DateTime podem ser comparados com ==.
+type DateTime struct {
+	t time.Time // Data e hora em UTC.
+}
+
+// NewDateTime retorna a data e hora informadas, normalizadas como em
+// time.Date.
+func NewDateTime(year int, month time.Month, day, hour, minute, second int) DateTime {
+	return DateTime{t: time.Date(year, month, day, hour, minute, second, 0, time.UTC)}
+}
+
+// DateTimeOf retorna a data e hora de t no fuso de t, sem frações de segundo.
+func DateTimeOf(t time.Time) DateTime {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+
+	return NewDateTime(year, month, day, hour, minute, second)
+}
+
+// ParseDateTime lê uma data com hora em qualquer dos formatos aceitos. Datas
+// sem hora são lidas como o início do dia.
+func ParseDateTime(s string) (DateTime, error) {
+	s = strings.TrimSpace(s)
+	if blank(s) {
+		return DateTime{}, nil
+	}
+
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateTimeOf(t), nil
+		}
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateTimeOf(t), nil
+		}
+	}
+
+	return DateTime{}, fmt.Errorf("imobdate: data e hora inválidas: %q", s)
+}
+
+// IsZero indica a data não informada.
+func (d DateTime) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Date retorna a data, sem a hora.
+func (d DateTime) Date() Date {
+	return DateOf(d.t)
+}
+
+// Clock retorna a hora, o minuto e o segundo.
+func (d DateTime) Clock() (hour, minute, second int) {
+	return d.t.Clock()
+}
+
+// Time retorna a data e hora no fuso informado.
+func (d DateTime) Time(loc *time.Location) time.Time {
+	year, month, day := d.t.Date()
+	hour, minute, second := d.t.Clock()
+
+	return time.Date(year, month, day, hour, minute, second, 0, loc)
+}
+
+// Add retorna a data e hora somada da duração informada.
+func (d DateTime) Add(duration time.Duration) DateTime {
+	return DateTime{t: d.t.Add(duration).Truncate(time.Second)}
+}
+
+func (d DateTime) Before(other DateTime) bool {
+	return d.t.Before(other.t)
+}
+
+func (d DateTime) After(other DateTime) bool {
+	return d.t.After(other.t)
+}
+
+// Compare retorna -1, 0 ou +1 se d for anterior, igual ou posterior a other.
+func (d DateTime) Compare(other DateTime) int {
+	return d.t.Compare(other.t)
+}
+
+// String retorna a data no formato DD/MM/YYYY hh:mm:ss, ou texto vazio para
+// a data zero.
+func (d DateTime) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.t.Format(LayoutDateTime)
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalText(data)
+	if !ok || err != nil {
+		return err
+	}
+
+	dateTime, err := ParseDateTime(s)
+	if err != nil {
+		return err
+	}
+
+	*d = dateTime
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package imobtest
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/session"
+)
+
+// SessionExpired é a resposta do Imobiliar para sessões inválidas ou expiradas.
+const SessionExpired = "468 - session expired, new login required"
+
+// Request é uma requisição recebida pelo servidor falso.
+type Request struct {
+	Action    string
+	SessionId string
+	Body      json.RawMessage
+}
+
+// Decode preenche v com o Body da requisição.
+func (r *Request) Decode(v any) error {
+	if len(r.Body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(r.Body, v)
+}
+
+// HandlerFunc responde a uma action. O valor retornado vira o Body da resposta.
+// Se o erro for um *erros.APIError, seus Erros são enviados em Body.Erros;
+// qualquer outro erro é enviado como um único Erro com a mensagem.
+type HandlerFunc func(req *Request) (any, error)
+
+// Server é um servidor HTTP em processo que fala o envelope JSON do Imobiliar.
+type Server struct {
+	*httptest.Server
+
+	imobId      string
+	userId      string
+	userPass    string // Hash MD5, como enviado no LOGIN.
+	rawPass     string
+	maxSessions int
+	sessionTTL  time.Duration
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	sessions map[string]time.Time // SessionId -> último uso.
+	nextId   int
+	requests []*Request
+}
+
+type NewInput struct {
+	ImobId      string        // Valor default é "IMOB".
+	UserId      string        // Valor default é "USUARIO".
+	UserPass    string        // Senha em texto puro. Valor default é "SENHA".
+	MaxSessions int           // Valor default é 5.
+	SessionTTL  time.Duration // Tempo sem uso até a sessão expirar. Se 0, não expira.
+}
+
+// New inicia um servidor falso. Deve ser encerrado com Close.
+func New(input *NewInput) *Server {
+	if input == nil {
+		input = &NewInput{}
+	}
+
+	rawPass := defaultString(input.UserPass, "SENHA")
+
+	s := &Server{
+		imobId:      defaultString(input.ImobId, "IMOB"),
+		userId:      defaultString(input.UserId, "USUARIO"),
+		userPass:    fmt.Sprintf("%x", md5.Sum([]byte(strings.ToUpper(rawPass)))),
+		rawPass:     rawPass,
+		maxSessions: input.MaxSessions,
+		sessionTTL:  input.SessionTTL,
+		handlers:    map[string]HandlerFunc{},
+		sessions:    map[string]time.Time{},
+	}
+
+	if s.maxSessions <= 0 {
+		s.maxSessions = 5
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// NewSessionInput retorna as credenciais válidas para este servidor.
+func (s *Server) NewSessionInput() *session.NewInput {
+	return &session.NewInput{
+		Endpoint: s.URL,
+		ImobId:   s.imobId,
+		UserId:   s.userId,
+		UserPass: s.rawPass,
+	}
+}
+
+// NewSession faz LOGIN no servidor com as credenciais válidas.
+func (s *Server) NewSession() (*session.Session, error) {
+	return session.NewSession(s.NewSessionInput())
+}
+
+// Handle registra a função que responde à action.
+func (s *Server) Handle(action string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[action] = fn
+}
+
+// Respond registra uma resposta fixa para a action.
+func (s *Server) Respond(action string, body any) {
+	s.Handle(action, func(*Request) (any, error) {
+		return body, nil
+	})
+}
+
+// Fail faz a action responder com Header.Error e os erros informados em Body.Erros.
+func (s *Server) Fail(action string, list ...erros.Erro) {
+	s.Handle(action, func(*Request) (any, error) {
+		return nil, Erros(list...)
+	})
+}
+
+// Erros monta o erro retornado por um HandlerFunc com os erros de campo informados.
+func Erros(list ...erros.Erro) error {
+	return &erros.APIError{Erros: list}
+}
+
+// ExpireSessions invalida todas as sessões abertas. A próxima chamada de cada
+// uma recebe a resposta SessionExpired.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]time.Time{}
+}
+
+// Sessions retorna a quantidade de sessões abertas.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// Requests retorna as requisições recebidas, em ordem.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var envelope struct {
+		Header struct {
+			SessionId string `json:"SessionId"`
+			Action    string `json:"Action"`
+		} `json:"Header"`
+		Body json.RawMessage `json:"Body"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &Request{
+		Action:    envelope.Header.Action,
+		SessionId: envelope.Header.SessionId,
+		Body:      envelope.Body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	switch req.Action {
+	case "LOGIN":
+		s.login(w, req)
+	case "LOGOUT":
+		s.logout(w, req)
+	default:
+		s.action(w, req)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, req *Request) {
+	var body struct {
+		ImobId   string `json:"IMOB_ID"`
+		UserId   string `json:"USER_ID"`
+		UserPass string `json:"USER_PASS"`
+	}
+	req.Decode(&body)
+
+	if body.ImobId != s.imobId || body.UserId != s.userId || body.UserPass != s.userPass {
+		writeResponse(w, req, "", nil, Erros(erros.Erro{Mensagem: "Usuário ou senha inválidos"}))
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.sessions) >= s.maxSessions {
+		s.mu.Unlock()
+		writeResponse(w, req, "", nil, Erros(erros.Erro{Mensagem: "Limite de sessões simultâneas atingido"}))
+		return
+	}
+
+	s.nextId++
+	sessionId := fmt.Sprintf("SESSION-%d", s.nextId)
+	s.sessions[sessionId] = time.Now()
+	s.mu.Unlock()
+
+	writeResponse(w, req, sessionId, map[string]any{
+		"NomeImob":       "Administradora " + s.imobId,
+		"ImobId":         s.imobId,
+		"UsuarioId":      s.userId,
+		"Nome":           "Usuário " + s.userId,
+		"Versao":         "imobtest",
+		"Client_IP":      "127.0.0.1",
+		"CodFilial":      1,
+		"NomeFilial":     "Matriz",
+		"Cidade":         "Porto Alegre",
+		"UF":             "RS",
+		"MaxSessions":    s.maxSessions,
+		"ServerDateTime": time.Now().Format("02/01/2006 15:04:05"),
+	}, nil)
+}
+
+func (s *Server) logout(w http.ResponseWriter, req *Request) {
+	s.mu.Lock()
+	delete(s.sessions, req.SessionId)
+	s.mu.Unlock()
+
+	writeResponse(w, req, req.SessionId, nil, nil)
+}
+
+func (s *Server) action(w http.ResponseWriter, req *Request) {
+	s.mu.Lock()
+	lastUsed, ok := s.sessions[req.SessionId]
+	if ok && s.sessionTTL > 0 && time.Since(lastUsed) > s.sessionTTL {
+		delete(s.sessions, req.SessionId)
+		ok = false
+	}
+	if ok {
+		s.sessions[req.SessionId] = time.Now()
+	}
+	handler := s.handlers[req.Action]
+	s.mu.Unlock()
+
+	if !ok {
+		w.Write([]byte(SessionExpired))
+		return
+	}
+
+	if handler == nil {
+		writeResponse(w, req, req.SessionId, nil, Erros(erros.Erro{Mensagem: "Action não implementada: " + req.Action}))
+		return
+	}
+
+	body, err := handler(req)
+	writeResponse(w, req, req.SessionId, body, err)
+}
+
+func writeResponse(w http.ResponseWriter, req *Request, sessionId string, body any, err error) {
+	header := map[string]any{
+		"SessionId": sessionId,
+		"Action":    req.Action,
+		"Status":    "OK",
+		"Error":     false,
+	}
+
+	if err != nil {
+		var apiError *erros.APIError
+		if !errors.As(err, &apiError) {
+			apiError = &erros.APIError{Erros: []erros.Erro{{Mensagem: err.Error()}}}
+		}
+
+		header["Status"] = "ERROR"
+		header["Error"] = true
+		header["ErrorCode"] = max(apiError.ErrorCode, 1)
+		body = map[string]any{"Erros": apiError.Erros}
+	}
+
+	if body == nil {
+		body = map[string]any{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Header": header,
+		"Body":   body,
+	})
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
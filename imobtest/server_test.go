@@ -0,0 +1,198 @@
+package imobtest_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/session"
+)
+
+func TestServerLogin(t *testing.T) {
+	srv := imobtest.New(&imobtest.NewInput{MaxSessions: 1})
+	defer srv.Close()
+
+	sess, err := srv.NewSession()
+	if err != nil {
+		t.Fatalf("LOGIN com credenciais válidas: %v", err)
+	}
+	defer sess.EndSession()
+
+	tests := []struct {
+		name      string
+		input     func(*session.NewInput)
+		wantErr   string
+		wantCreds bool
+	}{
+		{"senha inválida", func(in *session.NewInput) { in.UserPass = "ERRADA" }, "Usuário ou senha inválidos", true},
+		{"usuário inválido", func(in *session.NewInput) { in.UserId = "OUTRO" }, "Usuário ou senha inválidos", true},
+		{"limite de sessões", func(*session.NewInput) {}, "Limite de sessões simultâneas atingido", false},
+	}
+
+	for _, tt := range tests {
+		input := srv.NewSessionInput()
+		tt.input(input)
+
+		_, err := session.NewSession(input)
+
+		var apiError *erros.APIError
+		if !errors.As(err, &apiError) || apiError.Error() != tt.wantErr {
+			t.Errorf("%s: erro = %v, esperado %q", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		if got := errors.Is(err, erros.ErrCredenciais); got != tt.wantCreds {
+			t.Errorf("%s: errors.Is(ErrCredenciais) = %v, esperado %v", tt.name, got, tt.wantCreds)
+		}
+	}
+
+	if got := srv.Sessions(); got != 1 {
+		t.Errorf("%d sessões abertas, esperado 1", got)
+	}
+}
+
+func TestServerRespondFail(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	sess, err := srv.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.EndSession()
+
+	codPessoa := 7
+
+	tests := []struct {
+		name    string
+		setup   func()
+		want    string
+		wantErr error
+	}{
+		{"Respond", func() {
+			srv.Respond(cadastro_pessoa_consultar.ACTION, map[string]any{"CodPessoa": 7, "Nome": "Fulano"})
+		}, "Fulano", nil},
+		{"Handle", func() {
+			srv.Handle(cadastro_pessoa_consultar.ACTION, func(req *imobtest.Request) (any, error) {
+				var body cadastro_pessoa_consultar.ActionInput
+				if err := req.Decode(&body); err != nil {
+					return nil, err
+				}
+				return map[string]any{"CodPessoa": *body.CodPessoa, "Nome": "Pessoa 7"}, nil
+			})
+		}, "Pessoa 7", nil},
+		{"Fail com campo", func() {
+			srv.Fail(cadastro_pessoa_consultar.ACTION, erros.Erro{Campo: "CodPessoa", Mensagem: "Campo inválido"})
+		}, "", erros.ErrValidacao},
+		{"Fail sem campo", func() {
+			srv.Fail(cadastro_pessoa_consultar.ACTION, erros.Erro{Mensagem: "Pessoa não encontrada"})
+		}, "", erros.ErrNaoEncontrado},
+	}
+
+	for _, tt := range tests {
+		tt.setup()
+
+		output, err := cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+			Session:     sess,
+			ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: &codPessoa},
+		})
+
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: erro = %v, esperado %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+
+		if err != nil || output.Nome == nil || *output.Nome != tt.want {
+			t.Errorf("%s: resposta = %+v, %v, esperado Nome %q", tt.name, output, err, tt.want)
+		}
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 5 || requests[0].Action != "LOGIN" || requests[1].Action != cadastro_pessoa_consultar.ACTION {
+		t.Fatalf("requisições recebidas = %d", len(requests))
+	}
+
+	if requests[1].SessionId != sess.SessionId {
+		t.Errorf("SessionId = %q, esperado %q", requests[1].SessionId, sess.SessionId)
+	}
+}
+
+func TestServerActionNaoImplementada(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	sess, err := srv.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.EndSession()
+
+	codPessoa := 1
+	_, err = cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+		Session:     sess,
+		ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: &codPessoa},
+	})
+
+	var apiError *erros.APIError
+	if !errors.As(err, &apiError) || apiError.Error() != "Action não implementada: "+cadastro_pessoa_consultar.ACTION {
+		t.Errorf("erro = %v", err)
+	}
+}
+
+func TestServerSessaoExpirada(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *imobtest.NewInput
+		expire func(*imobtest.Server)
+	}{
+		{"ExpireSessions", nil, (*imobtest.Server).ExpireSessions},
+		{"SessionTTL", &imobtest.NewInput{SessionTTL: 10 * time.Millisecond}, func(*imobtest.Server) {
+			time.Sleep(20 * time.Millisecond)
+		}},
+	}
+
+	for _, tt := range tests {
+		srv := imobtest.New(tt.input)
+		srv.Respond(cadastro_pessoa_consultar.ACTION, map[string]any{"CodPessoa": 1})
+
+		sess, err := srv.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expired := sess.SessionId
+		tt.expire(srv)
+
+		codPessoa := 1
+		_, err = cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+			Session:     sess,
+			ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: &codPessoa},
+		})
+		if err != nil {
+			t.Errorf("%s: erro após novo login: %v", tt.name, err)
+		}
+
+		if sess.SessionId == expired {
+			t.Errorf("%s: sessão não foi renovada", tt.name)
+		}
+
+		var actions []string
+		for _, req := range srv.Requests() {
+			actions = append(actions, req.Action)
+		}
+
+		want := []string{"LOGIN", cadastro_pessoa_consultar.ACTION, "LOGIN", cadastro_pessoa_consultar.ACTION}
+		if !slices.Equal(actions, want) {
+			t.Errorf("%s: requisições = %v, esperado %v", tt.name, actions, want)
+		}
+
+		sess.EndSession()
+		srv.Close()
+	}
+}
@@ -0,0 +1,843 @@
+package imobtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_pesquisar"
+	"github.com/itispx/goimobiliar/actions/condom_condominio_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_condominio_pesquisar"
+	"github.com/itispx/goimobiliar/actions/condom_economia_alterar"
+	"github.com/itispx/goimobiliar/actions/condom_economia_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_economia_incluir"
+	"github.com/itispx/goimobiliar/actions/condom_lista_economias"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_cancelar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_consultar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_pesquisar_naopagos"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_quitar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_pesquisar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_pesquisar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_alterar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_incluir"
+	"github.com/itispx/goimobiliar/erros"
+)
+
+// Record é um registro do simulador, com os campos como enviados no JSON.
+type Record = map[string]any
+
+// Simulator mantém em memória pessoas, fornecedores, condomínios com blocos e
+// economias, imóveis, contratos e boletos, e responde às actions de cadastro e
+// consulta desses registros no Server.
+//
+// As actions *_INCLUIR atribuem códigos sequenciais, *_ALTERAR atualizam os
+// campos enviados e *_CONSULTAR e *_PESQUISAR leem os registros de volta. As
+// pesquisas respeitam QtdeLinhas e ProximasLinhas, guardando o próximo
+// segmento por sessão. Condomínios e boletos não têm action de inclusão e são
+// criados com AddCondominio e AddBoleto.
+type Simulator struct {
+	mu           sync.Mutex
+	pessoas      *table
+	fornecedores *table
+	condominios  *table
+	economias    *table
+	imoveis      *table
+	contratosLoc *table
+	contratosAdm *table
+	boletos      *table
+	cursors      map[string][]Record // SessionId e action -> linhas restantes.
+}
+
+// NewSimulator registra no servidor os handlers das actions simuladas.
+func NewSimulator(server *Server) *Simulator {
+	s := &Simulator{
+		pessoas:      newTable("CodPessoa"),
+		fornecedores: newTable("CodFornecedor"),
+		condominios:  newTable("CodCondominio"),
+		economias:    newTable("IdEconomia"),
+		imoveis:      newTable("CodImovel"),
+		contratosLoc: newTable("CodContratoLoc"),
+		contratosAdm: newTable("CodContratoAdm"),
+		boletos:      newTable("DocCapaId"),
+		cursors:      map[string][]Record{},
+	}
+
+	handlers := map[string]func(req *Request, body Record) (any, error){
+		cadastro_pessoa_incluir.ACTION:           s.pessoaIncluir,
+		cadastro_pessoa_consultar.ACTION:         s.pessoaConsultar,
+		cadastro_pessoa_alterar.ACTION:           s.pessoaAlterar,
+		cadastro_pessoa_pesquisar.ACTION:         s.pessoaPesquisar,
+		cadastro_fornecedor_incluir.ACTION:       s.fornecedorIncluir,
+		cadastro_fornecedor_consultar.ACTION:     s.fornecedorConsultar,
+		cadastro_fornecedor_alterar.ACTION:       s.fornecedorAlterar,
+		cadastro_fornecedor_pesquisar.ACTION:     s.fornecedorPesquisar,
+		condom_condominio_consultar.ACTION:       s.condominioConsultar,
+		condom_condominio_pesquisar.ACTION:       s.condominioPesquisar,
+		condom_economia_incluir.ACTION:           s.economiaIncluir,
+		condom_economia_consultar.ACTION:         s.economiaConsultar,
+		condom_economia_alterar.ACTION:           s.economiaAlterar,
+		condom_lista_economias.ACTION:            s.listaEconomias,
+		locacao_imovel_incluir.ACTION:            s.imovelIncluir,
+		locacao_imovel_consultar.ACTION:          s.imovelConsultar,
+		locacao_imovel_alterar.ACTION:            s.imovelAlterar,
+		locacao_contrato_imovel_incluir.ACTION:   s.contratoLocIncluir,
+		locacao_contrato_imovel_consultar.ACTION: s.contratoLocConsultar,
+		locacao_contrato_imovel_pesquisar.ACTION: s.contratoLocPesquisar,
+		locacao_contrato_adm_incluir.ACTION:      s.contratoAdmIncluir,
+		locacao_contrato_adm_consultar.ACTION:    s.contratoAdmConsultar,
+		locacao_contrato_adm_pesquisar.ACTION:    s.contratoAdmPesquisar,
+		ctarec_boleto_consultar.ACTION:           s.boletoConsultar,
+		ctarec_boleto_pesquisar_naopagos.ACTION:  s.boletoPesquisarNaoPagos,
+		ctarec_boleto_quitar.ACTION:              s.boletoQuitar,
+		ctarec_boleto_cancelar.ACTION:            s.boletoCancelar,
+	}
+
+	for action, fn := range handlers {
+		server.Handle(action, func(req *Request) (any, error) {
+			var body Record
+			if err := req.Decode(&body); err != nil {
+				return nil, err
+			}
+			for k, v := range body {
+				if v == nil {
+					delete(body, k)
+				}
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return fn(req, body)
+		})
+	}
+
+	return s
+}
+
+// AddCondominio inclui um condomínio, com seus Blocos, e retorna o código
+// atribuído. record pode ser um Record ou uma struct serializável, como
+// condom_condominio_consultar.RequestResponseBody.
+func (s *Simulator) AddCondominio(record any) (int, error) {
+	r, err := toRecord(record)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.condominios.insert(r), nil
+}
+
+// AddBoleto inclui um boleto e retorna o DocCapaId atribuído. Se o NossoNumero
+// não for informado, ele é gerado a partir do DocCapaId.
+func (s *Simulator) AddBoleto(record any) (int, error) {
+	r, err := toRecord(record)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.boletos.insert(r)
+	if _, ok := r["NossoNumero"]; !ok {
+		r["NossoNumero"] = fmt.Sprintf("%013d", id)
+	}
+
+	return id, nil
+}
+
+func (s *Simulator) pessoaIncluir(req *Request, body Record) (any, error) {
+	return build[cadastro_pessoa_incluir.RequestResponseBody](Record{"CodPessoa": s.pessoas.insert(body)}), nil
+}
+
+func (s *Simulator) pessoaConsultar(req *Request, body Record) (any, error) {
+	pessoa, err := s.pessoas.find(body, "Pessoa")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[cadastro_pessoa_consultar.RequestResponseBody](pessoa), nil
+}
+
+func (s *Simulator) pessoaAlterar(req *Request, body Record) (any, error) {
+	pessoa, err := s.pessoas.update(body, "Pessoa")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[cadastro_pessoa_alterar.RequestResponseBody](pessoa), nil
+}
+
+func (s *Simulator) pessoaPesquisar(req *Request, body Record) (any, error) {
+	rows := s.search(req, body, s.pessoas.list(), func(r Record) []any {
+		return []any{r["Nome"], r["CpfCnpj"], r["Email"]}
+	})
+
+	return build[cadastro_pessoa_pesquisar.RequestResponseBody](Record{"Pessoas": anySlice(rows)}), nil
+}
+
+func (s *Simulator) fornecedorIncluir(req *Request, body Record) (any, error) {
+	return build[cadastro_fornecedor_incluir.RequestResponseBody](Record{"CodFornecedor": s.fornecedores.insert(body)}), nil
+}
+
+func (s *Simulator) fornecedorConsultar(req *Request, body Record) (any, error) {
+	var fornecedor Record
+	if _, ok := body["CodFornecedor"]; ok {
+		var err error
+		if fornecedor, err = s.fornecedores.find(body, "Fornecedor"); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, r := range s.fornecedores.list() {
+			if body["CpfCnpj"] != nil && text(r["CpfCnpj"]) == text(body["CpfCnpj"]) {
+				fornecedor = r
+				break
+			}
+		}
+		if fornecedor == nil {
+			return nil, naoEncontrado("Fornecedor")
+		}
+	}
+
+	return build[cadastro_fornecedor_consultar.RequestResponseBody](fornecedor), nil
+}
+
+func (s *Simulator) fornecedorAlterar(req *Request, body Record) (any, error) {
+	fornecedor, err := s.fornecedores.update(body, "Fornecedor")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[cadastro_fornecedor_alterar.RequestResponseBody](fornecedor), nil
+}
+
+func (s *Simulator) fornecedorPesquisar(req *Request, body Record) (any, error) {
+	rows := s.search(req, body, s.fornecedores.list(), func(r Record) []any {
+		return []any{r["Nome"], r["NomeFantasia"], r["CpfCnpj"]}
+	})
+
+	return build[cadastro_fornecedor_pesquisar.RequestResponseBody](Record{"Fornecedores": anySlice(rows)}), nil
+}
+
+func (s *Simulator) condominioConsultar(req *Request, body Record) (any, error) {
+	condominio, err := s.condominios.find(body, "Condomínio")
+	if err != nil {
+		return nil, err
+	}
+
+	out := maps.Clone(condominio)
+	out["TotaldeBlocos"] = len(s.blocos(condominio))
+
+	return build[condom_condominio_consultar.RequestResponseBody](out), nil
+}
+
+func (s *Simulator) condominioPesquisar(req *Request, body Record) (any, error) {
+	var rows []Record
+	for _, r := range s.condominios.list() {
+		rows = append(rows, Record{
+			"CodCondominio":  r["CodCondominio"],
+			"NomeCondominio": r["NomeCondominio"],
+			"Endereco":       r["EnderecoPrincipal"],
+		})
+	}
+
+	rows = s.search(req, body, rows, func(r Record) []any {
+		return []any{r["NomeCondominio"], r["Endereco"]}
+	})
+
+	return build[condom_condominio_pesquisar.RequestResponseBody](Record{"Condominios": anySlice(rows)}), nil
+}
+
+func (s *Simulator) economiaIncluir(req *Request, body Record) (any, error) {
+	if _, err := s.condominios.find(body, "Condomínio"); err != nil {
+		return nil, err
+	}
+
+	return build[condom_economia_incluir.RequestResponseBody](Record{"IdEconomia": s.economias.insert(body)}), nil
+}
+
+func (s *Simulator) economiaConsultar(req *Request, body Record) (any, error) {
+	economia, err := s.economias.find(body, "Economia")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[condom_economia_consultar.RequestResponseBody](economia), nil
+}
+
+func (s *Simulator) economiaAlterar(req *Request, body Record) (any, error) {
+	economia, err := s.economias.update(body, "Economia")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[condom_economia_alterar.RequestResponseBody](economia), nil
+}
+
+func (s *Simulator) listaEconomias(req *Request, body Record) (any, error) {
+	condominio, err := s.condominios.find(body, "Condomínio")
+	if err != nil {
+		return nil, err
+	}
+
+	var blocos []any
+	total := 0
+	for _, bloco := range s.blocos(condominio) {
+		codBloco := text(bloco["CodBloco"])
+		if body["CodBloco"] != nil && text(body["CodBloco"]) != codBloco {
+			continue
+		}
+
+		var economias []any
+		for _, economia := range s.economias.list() {
+			if text(economia["CodCondominio"]) != text(condominio["CodCondominio"]) || text(economia["CodBloco"]) != codBloco {
+				continue
+			}
+
+			item := maps.Clone(economia)
+			if pessoa, ok := s.pessoas.get(economia["CodPessoaCondomino"]); ok {
+				item["Nome"] = pessoa["Nome"]
+				item["CpfCnpj"] = pessoa["CpfCnpj"]
+				item["Celular"] = pessoa["Celular"]
+				item["Email"] = pessoa["Email"]
+			}
+			if pessoa, ok := s.pessoas.get(economia["CodPessoaLocat"]); ok {
+				item["Locatario"] = pessoa["Nome"]
+			}
+			economias = append(economias, item)
+		}
+
+		item := maps.Clone(bloco)
+		item["NomeBloco"] = bloco["Descricao"]
+		item["QtdeEconomias"] = len(economias)
+		item["Economias"] = economias
+		blocos = append(blocos, item)
+		total += len(economias)
+	}
+
+	out := maps.Clone(condominio)
+	out["TotaldeEconomias"] = total
+	out["TotaldeBlocos"] = len(blocos)
+	out["Blocos"] = blocos
+
+	return build[condom_lista_economias.RequestResponseBody](out), nil
+}
+
+// blocos retorna os Blocos do condomínio, incluindo os blocos usados por
+// economias que não foram cadastrados no condomínio.
+func (s *Simulator) blocos(condominio Record) []Record {
+	var blocos []Record
+	seen := map[string]bool{}
+	if list, ok := condominio["Blocos"].([]any); ok {
+		for _, item := range list {
+			if bloco, ok := item.(Record); ok {
+				blocos = append(blocos, bloco)
+				seen[text(bloco["CodBloco"])] = true
+			}
+		}
+	}
+
+	for _, economia := range s.economias.list() {
+		codBloco := text(economia["CodBloco"])
+		if text(economia["CodCondominio"]) == text(condominio["CodCondominio"]) && !seen[codBloco] {
+			blocos = append(blocos, Record{"CodBloco": economia["CodBloco"]})
+			seen[codBloco] = true
+		}
+	}
+
+	return blocos
+}
+
+func (s *Simulator) imovelIncluir(req *Request, body Record) (any, error) {
+	return build[locacao_imovel_incluir.RequestResponseBody](Record{"CodImovel": s.imoveis.insert(body)}), nil
+}
+
+func (s *Simulator) imovelConsultar(req *Request, body Record) (any, error) {
+	imovel, err := s.imoveis.find(body, "Imóvel")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[locacao_imovel_consultar.RequestResponseBody](imovel), nil
+}
+
+func (s *Simulator) imovelAlterar(req *Request, body Record) (any, error) {
+	imovel, err := s.imoveis.update(body, "Imóvel")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[locacao_imovel_alterar.RequestResponseBody](imovel), nil
+}
+
+func (s *Simulator) contratoLocIncluir(req *Request, body Record) (any, error) {
+	if _, err := s.imoveis.find(body, "Imóvel"); err != nil {
+		return nil, err
+	}
+	if _, ok := s.pessoas.get(body["CodPessoaLocat"]); !ok {
+		return nil, naoEncontrado("Locatário")
+	}
+
+	codContratoLoc := s.contratosLoc.insert(body)
+
+	return build[locacao_contrato_imovel_incluir.RequestResponseBody](Record{
+		"CodImovel":      body["CodImovel"],
+		"CodContratoLoc": codContratoLoc,
+		"CodPessoaLocat": body["CodPessoaLocat"],
+	}), nil
+}
+
+func (s *Simulator) contratoLocConsultar(req *Request, body Record) (any, error) {
+	if _, ok := body["CodContratoLoc"]; ok {
+		contrato, err := s.contratosLoc.find(body, "Contrato de locação")
+		if err != nil {
+			return nil, err
+		}
+
+		return build[locacao_contrato_imovel_consultar.RequestResponseBody](contrato), nil
+	}
+
+	// Sem CodContratoLoc, retorna o contrato mais recente do imóvel.
+	var contrato Record
+	for _, r := range s.contratosLoc.list() {
+		if body["CodImovel"] != nil && text(r["CodImovel"]) == text(body["CodImovel"]) {
+			contrato = r
+		}
+	}
+	if contrato == nil {
+		return nil, naoEncontrado("Contrato de locação")
+	}
+
+	return build[locacao_contrato_imovel_consultar.RequestResponseBody](contrato), nil
+}
+
+func (s *Simulator) contratoLocPesquisar(req *Request, body Record) (any, error) {
+	var rows []Record
+	for _, r := range s.contratosLoc.list() {
+		row := Record{"CodImovel": r["CodImovel"], "Ativo": "S"}
+		if imovel, ok := s.imoveis.get(r["CodImovel"]); ok {
+			row["Endereco"] = endereco(imovel)
+		}
+		if pessoa, ok := s.pessoas.get(r["CodPessoaLocat"]); ok {
+			row["NomeLocat"] = pessoa["Nome"]
+		}
+		rows = append(rows, row)
+	}
+
+	rows = s.search(req, body, rows, func(r Record) []any {
+		return []any{r["CodImovel"], r["Endereco"], r["NomeLocat"]}
+	})
+
+	return build[locacao_contrato_imovel_pesquisar.RequestResponseBody](Record{"Contratos": anySlice(rows)}), nil
+}
+
+func (s *Simulator) contratoAdmIncluir(req *Request, body Record) (any, error) {
+	if _, ok := s.pessoas.get(body["CodPessoaTitular"]); !ok {
+		return nil, naoEncontrado("Titular")
+	}
+
+	return build[locacao_contrato_adm_incluir.RequestResponseBody](Record{"CodContratoAdm": s.contratosAdm.insert(body)}), nil
+}
+
+func (s *Simulator) contratoAdmConsultar(req *Request, body Record) (any, error) {
+	contrato, err := s.contratosAdm.find(body, "Contrato de administração")
+	if err != nil {
+		return nil, err
+	}
+
+	return build[locacao_contrato_adm_consultar.RequestResponseBody](contrato), nil
+}
+
+func (s *Simulator) contratoAdmPesquisar(req *Request, body Record) (any, error) {
+	var rows []Record
+	for _, r := range s.contratosAdm.list() {
+		row := Record{"CodContratoAdm": r["CodContratoAdm"]}
+		if pessoa, ok := s.pessoas.get(r["CodPessoaTitular"]); ok {
+			row["NomeProprietario"] = pessoa["Nome"]
+		}
+		// O imóvel é o primeiro contrato de locação que referencia este contrato.
+		for _, contrato := range s.contratosLoc.list() {
+			if text(contrato["CodContratoAdm"]) != text(r["CodContratoAdm"]) {
+				continue
+			}
+			row["CodImovel"] = contrato["CodImovel"]
+			if imovel, ok := s.imoveis.get(contrato["CodImovel"]); ok {
+				row["Endereco"] = endereco(imovel)
+			}
+			break
+		}
+		rows = append(rows, row)
+	}
+
+	rows = s.search(req, body, rows, func(r Record) []any {
+		return []any{r["CodContratoAdm"], r["NomeProprietario"], r["Endereco"]}
+	})
+
+	return build[locacao_contrato_adm_pesquisar.RequestResponseBody](Record{"Contratos": anySlice(rows)}), nil
+}
+
+func (s *Simulator) boletoConsultar(req *Request, body Record) (any, error) {
+	boleto, err := s.boleto(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return build[ctarec_boleto_consultar.RequestResponseBody](boleto), nil
+}
+
+func (s *Simulator) boletoPesquisarNaoPagos(req *Request, body Record) (any, error) {
+	var boletos []any
+	for _, r := range s.boletos.list() {
+		if text(r["CodPessoa"]) != text(body["CodPessoa"]) || r["DataPagamento"] != nil || text(r["Cancelado"]) == "S" {
+			continue
+		}
+
+		boletos = append(boletos, Record{
+			"DocCapaId":   r["DocCapaId"],
+			"NossoNumero": r["NossoNumero"],
+			"DataVenc":    r["DataVenc"],
+			"Origem":      r["OrigemCobranca"],
+		})
+	}
+
+	return build[ctarec_boleto_pesquisar_naopagos.RequestResponseBody](Record{
+		"CodPessoa": body["CodPessoa"],
+		"Boletos":   boletos,
+	}), nil
+}
+
+func (s *Simulator) boletoQuitar(req *Request, body Record) (any, error) {
+	boleto, err := s.boleto(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case text(boleto["Cancelado"]) == "S":
+		return nil, Erros(erros.Erro{Campo: "DocCapaId", Mensagem: "Boleto cancelado"})
+	case boleto["DataPagamento"] != nil:
+		return nil, Erros(erros.Erro{Campo: "DocCapaId", Mensagem: "Boleto já quitado"})
+	}
+
+	boleto["DataPagamento"] = body["DataPagamento"]
+	if vlrPagamento, ok := body["VlrPagamento"]; ok {
+		boleto["VlrPagamento"] = vlrPagamento
+	}
+
+	return build[ctarec_boleto_quitar.RequestResponseBody](Record{}), nil
+}
+
+func (s *Simulator) boletoCancelar(req *Request, body Record) (any, error) {
+	boleto, err := s.boleto(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if boleto["DataPagamento"] != nil {
+		return nil, Erros(erros.Erro{Campo: "DocCapaId", Mensagem: "Boleto já quitado"})
+	}
+
+	boleto["Cancelado"] = "S"
+
+	return build[ctarec_boleto_cancelar.RequestResponseBody](Record{"DocCapaId": boleto["DocCapaId"]}), nil
+}
+
+// boleto procura pelo DocCapaId ou, na falta dele, pelo NossoNumero.
+func (s *Simulator) boleto(body Record) (Record, error) {
+	if _, ok := body["DocCapaId"]; ok {
+		return s.boletos.find(body, "Boleto")
+	}
+
+	for _, r := range s.boletos.list() {
+		if body["NossoNumero"] != nil && text(r["NossoNumero"]) == text(body["NossoNumero"]) {
+			return r, nil
+		}
+	}
+
+	return nil, naoEncontrado("Boleto")
+}
+
+// search filtra as linhas pelo Texto da pesquisa e aplica a paginação de
+// QtdeLinhas e ProximasLinhas.
+func (s *Simulator) search(req *Request, body Record, rows []Record, fields func(Record) []any) []Record {
+	key := req.SessionId + " " + req.Action
+
+	if text(body["ProximasLinhas"]) == "S" {
+		rows = s.cursors[key]
+	} else if texto := strings.ToLower(text(body["Texto"])); texto != "" {
+		rows = slices.DeleteFunc(rows, func(r Record) bool {
+			return !slices.ContainsFunc(fields(r), func(v any) bool {
+				return strings.Contains(strings.ToLower(text(v)), texto)
+			})
+		})
+	}
+	delete(s.cursors, key)
+
+	qtdeLinhas, _ := code(body["QtdeLinhas"])
+	if qtdeLinhas > 0 && len(rows) > qtdeLinhas {
+		s.cursors[key] = rows[qtdeLinhas:]
+		rows = rows[:qtdeLinhas]
+	}
+
+	return rows
+}
+
+type table struct {
+	key  string
+	next int
+	rows map[int]Record
+}
+
+func newTable(key string) *table {
+	return &table{key: key, rows: map[int]Record{}}
+}
+
+func (t *table) insert(record Record) int {
+	t.next++
+	record[t.key] = t.next
+	t.rows[t.next] = record
+
+	return t.next
+}
+
+func (t *table) get(value any) (Record, bool) {
+	c, ok := code(value)
+	if !ok {
+		return nil, false
+	}
+
+	record, ok := t.rows[c]
+
+	return record, ok
+}
+
+// find procura o registro pelo código informado em body.
+func (t *table) find(body Record, name string) (Record, error) {
+	record, ok := t.get(body[t.key])
+	if !ok {
+		return nil, naoEncontrado(name)
+	}
+
+	return record, nil
+}
+
+// update copia os campos informados em body para o registro, exceto o código.
+func (t *table) update(body Record, name string) (Record, error) {
+	record, err := t.find(body, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range body {
+		if k != t.key {
+			record[k] = v
+		}
+	}
+
+	return record, nil
+}
+
+// list retorna os registros em ordem de código.
+func (t *table) list() []Record {
+	codes := make([]int, 0, len(t.rows))
+	for c := range t.rows {
+		codes = append(codes, c)
+	}
+	slices.Sort(codes)
+
+	list := make([]Record, 0, len(codes))
+	for _, c := range codes {
+		list = append(list, t.rows[c])
+	}
+
+	return list
+}
+
+func naoEncontrado(name string) error {
+	return Erros(erros.Erro{Mensagem: "Registro de " + strings.ToLower(name) + " não encontrado"})
+}
+
+func endereco(imovel Record) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s, %s", text(imovel["TipoLograd"]), text(imovel["Logradouro"]), text(imovel["Numero"])))
+}
+
+func toRecord(value any) (Record, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func anySlice(rows []Record) []any {
+	out := make([]any, len(rows))
+	for i, r := range rows {
+		out[i] = r
+	}
+
+	return out
+}
+
+func text(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func code(value any) (int, bool) {
+	c, err := strconv.Atoi(text(value))
+
+	return c, err == nil
+}
+
+// build converte o registro no tipo de resposta da action. Os campos são
+// convertidos entre número e texto quando os tipos de entrada e saída da
+// documentação divergem; campos que não podem ser convertidos são omitidos.
+func build[T any](record Record) *T {
+	var out T
+	if v, ok := convert(record, reflect.TypeOf(out)); ok {
+		reflect.ValueOf(&out).Elem().Set(v)
+	}
+
+	return &out
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+func convert(value any, typ reflect.Type) (reflect.Value, bool) {
+	out := reflect.New(typ).Elem()
+
+	// Tipos com UnmarshalJSON próprio, como enums.Flag, decodificam o valor.
+	if typ.Kind() != reflect.Pointer && reflect.PointerTo(typ).Implements(unmarshalerType) {
+		data, err := json.Marshal(value)
+		if err != nil || json.Unmarshal(data, out.Addr().Interface()) != nil {
+			return out, false
+		}
+		return out, true
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		elem, ok := convert(value, typ.Elem())
+		if !ok {
+			return out, false
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, true
+
+	case reflect.Struct:
+		record, ok := value.(Record)
+		if !ok {
+			return out, false
+		}
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name := jsonName(field)
+			if name == "" || record[name] == nil {
+				continue
+			}
+			if v, ok := convert(record[name], field.Type); ok {
+				out.Field(i).Set(v)
+			}
+		}
+		return out, true
+
+	case reflect.Slice:
+		list, ok := value.([]any)
+		if !ok {
+			return out, false
+		}
+		for _, item := range list {
+			if v, ok := convert(item, typ.Elem()); ok {
+				out = reflect.Append(out, v)
+			}
+		}
+		return out, true
+
+	case reflect.String:
+		if value == nil {
+			return out, false
+		}
+		out.SetString(text(value))
+		return out, true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := strconv.ParseFloat(text(value), 64)
+		if err != nil {
+			return out, false
+		}
+		out.SetInt(int64(f))
+		return out, true
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(text(value), ",", "."), 64)
+		if err != nil {
+			return out, false
+		}
+		out.SetFloat(f)
+		return out, true
+
+	case reflect.Bool:
+		b, ok := value.(bool)
+		out.SetBool(b)
+		return out, ok
+
+	case reflect.Interface:
+		if value == nil {
+			return out, false
+		}
+		out.Set(reflect.ValueOf(value))
+		return out, true
+	}
+
+	return out, false
+}
+
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
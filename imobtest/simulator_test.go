@@ -0,0 +1,264 @@
+package imobtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_pesquisar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_cancelar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_consultar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_pesquisar_naopagos"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_quitar"
+	"github.com/itispx/goimobiliar/doc"
+	"github.com/itispx/goimobiliar/enums"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobdate"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/money"
+	"github.com/itispx/goimobiliar/session"
+)
+
+func newSimulator(t *testing.T) (*imobtest.Simulator, *session.Session) {
+	t.Helper()
+
+	srv := imobtest.New(nil)
+	t.Cleanup(srv.Close)
+
+	sim := imobtest.NewSimulator(srv)
+
+	sess, err := srv.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sess.EndSession() })
+
+	return sim, sess
+}
+
+func TestSimulatorPessoa(t *testing.T) {
+	_, sess := newSimulator(t)
+
+	nome, cpf := "Fulano de Tal", doc.MustParse("012.345.678-90")
+	incluida, err := cadastro_pessoa_incluir.Run(&cadastro_pessoa_incluir.RunInput{
+		Session: sess,
+		ActionInput: &cadastro_pessoa_incluir.ActionInput{
+			Nome:       &nome,
+			TipoPessoa: &[]enums.TipoPessoa{enums.PessoaFisica}[0],
+			CpfCnpj:    &cpf,
+			Ativo:      enums.NewFlag(true),
+		},
+	})
+	if err != nil || incluida.CodPessoa == nil || *incluida.CodPessoa != 1 {
+		t.Fatalf("INCLUIR = %+v, %v", incluida, err)
+	}
+
+	novoNome, tipoConta, codBanco, codAgencia, contaCorrente := "Fulano de Tal Jr.", "C", 1, 1234, "56789-0"
+	if _, err := cadastro_pessoa_alterar.Run(&cadastro_pessoa_alterar.RunInput{
+		Session: sess,
+		ActionInput: &cadastro_pessoa_alterar.ActionInput{
+			CodPessoa:     incluida.CodPessoa,
+			Nome:          &novoNome,
+			TipoConta:     &tipoConta,
+			CodBanco:      &codBanco,
+			CodAgencia:    &codAgencia,
+			ContaCorrente: &contaCorrente,
+		},
+	}); err != nil {
+		t.Fatalf("ALTERAR: %v", err)
+	}
+
+	tests := []struct {
+		codPessoa int
+		wantNome  string
+		wantErr   error
+	}{
+		{1, novoNome, nil},
+		{2, "", erros.ErrNaoEncontrado},
+	}
+
+	for _, tt := range tests {
+		pessoa, err := cadastro_pessoa_consultar.Run(&cadastro_pessoa_consultar.RunInput{
+			Session:     sess,
+			ActionInput: &cadastro_pessoa_consultar.ActionInput{CodPessoa: &tt.codPessoa},
+		})
+
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CONSULTAR %d: erro = %v, esperado %v", tt.codPessoa, err, tt.wantErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("CONSULTAR %d: %v", tt.codPessoa, err)
+			continue
+		}
+
+		if *pessoa.Nome != tt.wantNome || *pessoa.CpfCnpj != cpf || !bool(*pessoa.Ativo) || *pessoa.ContaCorrente != contaCorrente {
+			t.Errorf("CONSULTAR %d = %s, %s, %v, %s", tt.codPessoa, *pessoa.Nome, *pessoa.CpfCnpj, *pessoa.Ativo, *pessoa.ContaCorrente)
+		}
+	}
+}
+
+func TestSimulatorPesquisa(t *testing.T) {
+	_, sess := newSimulator(t)
+
+	for i := 1; i <= 5; i++ {
+		nome := fmt.Sprintf("Pessoa %d", i)
+		if i%2 == 0 {
+			nome = fmt.Sprintf("Empresa %d", i)
+		}
+
+		if _, err := cadastro_pessoa_incluir.Run(&cadastro_pessoa_incluir.RunInput{
+			Session:     sess,
+			ActionInput: &cadastro_pessoa_incluir.ActionInput{Nome: &nome},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		texto      string
+		qtdeLinhas int
+		want       []int
+	}{
+		{"todas em segmentos de 2", "", 2, []int{1, 2, 3, 4, 5}},
+		{"todas em um segmento", "", 10, []int{1, 2, 3, 4, 5}},
+		{"filtro por texto", "empresa", 1, []int{2, 4}},
+		{"sem resultados", "ninguém", 2, nil},
+	}
+
+	for _, tt := range tests {
+		input := &cadastro_pessoa_pesquisar.ActionInput{Texto: &tt.texto, QtdeLinhas: &tt.qtdeLinhas}
+
+		var got []int
+		for pessoa, err := range cadastro_pessoa_pesquisar.All(context.Background(), sess, input) {
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			got = append(got, *pessoa.CodPessoa)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: pessoas = %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSimulatorBoleto(t *testing.T) {
+	sim, sess := newSimulator(t)
+
+	vencimento := imobdate.NewDate(2024, time.March, 10)
+	for range 2 {
+		if _, err := sim.AddBoleto(imobtest.Record{
+			"CodPessoa":      7,
+			"OrigemCobranca": "L",
+			"DataVenc":       vencimento,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	naoPagos := func() []int {
+		codPessoa := 7
+		output, err := ctarec_boleto_pesquisar_naopagos.Run(&ctarec_boleto_pesquisar_naopagos.RunInput{
+			Session:     sess,
+			ActionInput: &ctarec_boleto_pesquisar_naopagos.ActionInput{CodPessoa: &codPessoa},
+		})
+		if err != nil {
+			t.Fatalf("PESQUISAR_NAOPAGOS: %v", err)
+		}
+
+		var ids []int
+		for _, boleto := range output.Boletos {
+			ids = append(ids, *boleto.DocCapaId)
+		}
+
+		return ids
+	}
+
+	quitar := func(docCapaId int) error {
+		nossoNumero := fmt.Sprintf("%013d", docCapaId)
+		zero, valor := money.Money(0), money.New(150, 0)
+		origem, complemento, codBanco := "TESTE", "Pago", 1
+
+		_, err := ctarec_boleto_quitar.Run(&ctarec_boleto_quitar.RunInput{
+			Session: sess,
+			ActionInput: &ctarec_boleto_quitar.ActionInput{
+				OrigemCobranca:            &[]enums.OrigemCobranca{enums.CobrancaLocacao}[0],
+				NossoNumero:               &nossoNumero,
+				DocCapaId:                 &docCapaId,
+				DataPagamento:             &vencimento,
+				OrigemQuitacao:            &origem,
+				VlrMultaAdministrativa:    &zero,
+				VlrDescontoAdministrativo: &zero,
+				VlrAcrescimoOutros:        &zero,
+				VlrDescontoProprietario:   &zero,
+				VlrDescontos:              &zero,
+				VlrAcrescimos:             &zero,
+				VlrPagamento:              &valor,
+				CodBanco:                  &codBanco,
+				Complemento:               &complemento,
+			},
+		})
+
+		return err
+	}
+
+	cancelar := func(docCapaId int) error {
+		origem, competencia := "L", vencimento.Competencia()
+		_, err := ctarec_boleto_cancelar.Run(&ctarec_boleto_cancelar.RunInput{
+			Session: sess,
+			ActionInput: &ctarec_boleto_cancelar.ActionInput{
+				Origem:      &origem,
+				DocCapaId:   &docCapaId,
+				Competencia: &competencia,
+			},
+		})
+
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		run         func() error
+		wantErr     error
+		wantPending []int
+	}{
+		{"inicial", func() error { return nil }, nil, []int{1, 2}},
+		{"quitar", func() error { return quitar(1) }, nil, []int{2}},
+		{"quitar de novo", func() error { return quitar(1) }, erros.ErrValidacao, []int{2}},
+		{"cancelar quitado", func() error { return cancelar(1) }, erros.ErrValidacao, []int{2}},
+		{"cancelar", func() error { return cancelar(2) }, nil, nil},
+		{"quitar cancelado", func() error { return quitar(2) }, erros.ErrValidacao, nil},
+		{"quitar inexistente", func() error { return quitar(3) }, erros.ErrNaoEncontrado, nil},
+	}
+
+	for _, tt := range tests {
+		err := tt.run()
+		if (tt.wantErr == nil && err != nil) || !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: erro = %v, esperado %v", tt.name, err, tt.wantErr)
+		}
+
+		if got := naoPagos(); !slices.Equal(got, tt.wantPending) {
+			t.Errorf("%s: não pagos = %v, esperado %v", tt.name, got, tt.wantPending)
+		}
+	}
+
+	nossoNumero := fmt.Sprintf("%013d", 1)
+	boleto, err := ctarec_boleto_consultar.Run(&ctarec_boleto_consultar.RunInput{
+		Session:     sess,
+		ActionInput: &ctarec_boleto_consultar.ActionInput{NossoNumero: &nossoNumero},
+	})
+	if err != nil || *boleto.DocCapaId != 1 || *boleto.CodPessoa != 7 {
+		t.Errorf("CONSULTAR = %+v, %v", boleto, err)
+	}
+}
@@ -0,0 +1,360 @@
+// Package money define o tipo dos valores monetários do Imobiliar, em
+// centavos inteiros, para que somas e arredondamentos não acumulem os erros
+// de float64.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalido é retornado por Parse quando o texto não é um valor monetário.
+var ErrInvalido = errors.New("money: valor inválido")
+
+// ErrDivisaoPorZero é retornado por Div quando o divisor é zero.
+var ErrDivisaoPorZero = errors.New("money: divisão por zero")
+
+// Money é um valor em reais, guardado em centavos. É enviado como número com
+// duas casas decimais, como 1234.56; para os campos de texto, use MoneyTexto.
+type Money int64
+
+// RoundingMode é a forma de arredondamento para centavos.
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // Metade para longe do zero (arredondamento comercial).
+	RoundHalfEven                     // Metade para o par mais próximo (arredondamento bancário).
+	RoundDown                         // Em direção ao zero (truncamento).
+	RoundUp                           // Para longe do zero.
+)
+
+// New retorna o valor de reais e centavos: New(10, 50) é R$ 10,50. Para
+// valores negativos, informe os dois com sinal negativo.
+func New(reais, centavos int64) Money {
+	return Money(reais*100 + centavos)
+}
+
+// FromFloat converte um float64 com arredondamento comercial (RoundHalfUp).
+func FromFloat(f float64) Money {
+	return FromFloatRounded(f, RoundHalfUp)
+}
+
+// FromFloatRounded converte um float64 com a forma de arredondamento
+// informada. O float64 é lido pela sua representação decimal mais curta, de
+// forma que 0.125 é arredondado como 0,125.
+func FromFloatRounded(f float64, mode RoundingMode) Money {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+
+	return roundRat(r.Mul(r, big.NewRat(100, 1)), mode)
+}
+
+// Parse lê um valor como 1234.56, 1234,56, 1.234,56 ou R$ 1.234,56. Casas
+// decimais além dos centavos são arredondadas com RoundHalfUp.
+func Parse(s string) (Money, error) {
+	text, negative := strings.TrimSpace(s), false
+	for _, prefix := range []string{"-", "R$", "-"} {
+		if strings.HasPrefix(text, prefix) {
+			negative = negative || prefix == "-"
+			text = strings.TrimSpace(strings.TrimPrefix(text, prefix))
+		}
+	}
+
+	if text == "" {
+		return 0, nil
+	}
+
+	text = normalize(text)
+	if strings.ContainsAny(text, "+-eE/") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalido, s)
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalido, s)
+	}
+
+	if negative {
+		r.Neg(r)
+	}
+
+	return roundRat(r.Mul(r, big.NewRat(100, 1)), RoundHalfUp), nil
+}
+
+// MustParse é como Parse, mas entra em pânico se o valor for inválido.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Sum retorna a soma dos valores.
+func Sum(values ...Money) Money {
+	var total Money
+	for _, value := range values {
+		total += value
+	}
+
+	return total
+}
+
+// Centavos retorna o valor em centavos.
+func (m Money) Centavos() int64 {
+	return int64(m)
+}
+
+// Float64 retorna o valor em reais. Use apenas para exibição ou cálculos que
+// não precisam ser exatos.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+func (m Money) Neg() Money {
+	return -m
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+
+	return m
+}
+
+// Sign retorna -1, 0 ou +1 conforme o sinal do valor.
+func (m Money) Sign() int {
+	switch {
+	case m < 0:
+		return -1
+	case m > 0:
+		return 1
+	}
+
+	return 0
+}
+
+// Mul retorna o valor multiplicado por n.
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+// MulRate retorna o valor multiplicado por rate, arredondado para centavos.
+// O fator é lido pela sua representação decimal mais curta: MulRate(0.1, ...)
+// multiplica por exatamente 0,1.
+func (m Money) MulRate(rate float64, mode RoundingMode) Money {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+
+	return roundRat(r.Mul(r, big.NewRat(int64(m), 1)), mode)
+}
+
+// Percent retorna percent por cento do valor, arredondado para centavos:
+// Percent(2, RoundHalfUp) de R$ 1.500,00 é R$ 30,00.
+func (m Money) Percent(percent float64, mode RoundingMode) Money {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	r.Mul(r, big.NewRat(int64(m), 100))
+
+	return roundRat(r, mode)
+}
+
+// Div retorna o valor dividido por n, arredondado para centavos, ou
+// ErrDivisaoPorZero se n for zero.
+func (m Money) Div(n int64, mode RoundingMode) (Money, error) {
+	if n == 0 {
+		return 0, ErrDivisaoPorZero
+	}
+
+	return roundRat(big.NewRat(int64(m), n), mode), nil
+}
+
+// Split divide o valor em n parcelas que somam exatamente o valor original. Os
+// centavos que sobram da divisão vão para as primeiras parcelas.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parcelas := make([]Money, n)
+	base, rest := m/Money(n), m%Money(n)
+
+	step := Money(1)
+	if rest < 0 {
+		step, rest = -1, -rest
+	}
+
+	for i := range parcelas {
+		parcelas[i] = base
+		if Money(i) < rest {
+			parcelas[i] += step
+		}
+	}
+
+	return parcelas
+}
+
+// Decimal retorna o valor com ponto decimal e duas casas, como 1234.56.
+func (m Money) Decimal() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+	}
+
+	centavos := m.Abs()
+
+	return fmt.Sprintf("%s%d.%02d", sign, centavos/100, centavos%100)
+}
+
+// String retorna o valor no formato brasileiro, como R$ 1.234,56 e
+// -R$ 0,50.
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+	}
+
+	centavos := m.Abs()
+	reais := strconv.FormatInt(int64(centavos/100), 10)
+
+	var b strings.Builder
+	for i, digit := range reais {
+		if i > 0 && (len(reais)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, b.String(), centavos%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON aceita o valor como número ou como texto em qualquer dos
+// formatos de Parse. Números são lidos pelo texto do JSON, sem passar por
+// float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	text := string(data)
+	if !strings.HasPrefix(text, `"`) {
+		r, ok := new(big.Rat).SetString(text)
+		if !ok || strings.Contains(text, "/") {
+			return fmt.Errorf("%w: %s", ErrInvalido, data)
+		}
+
+		*m = roundRat(r.Mul(r, big.NewRat(100, 1)), RoundHalfUp)
+
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	value, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*m = value
+
+	return nil
+}
+
+// MoneyTexto é um Money enviado como texto, como "1234.56", para as actions
+// que recebem o valor em um campo String.
+type MoneyTexto Money
+
+func (m MoneyTexto) String() string {
+	return Money(m).String()
+}
+
+func (m MoneyTexto) Float64() float64 {
+	return Money(m).Float64()
+}
+
+func (m MoneyTexto) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Money(m).Decimal())
+}
+
+func (m *MoneyTexto) UnmarshalJSON(data []byte) error {
+	return (*Money)(m).UnmarshalJSON(data)
+}
+
+// normalize converte os separadores para o formato 1234.56. Com ponto e
+// vírgula, o último é o separador decimal; só com vírgula, ela é o separador
+// decimal; só com pontos, mais de um ponto indica separador de milhar.
+func normalize(s string) string {
+	s = strings.ReplaceAll(s, " ", "")
+
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+
+	switch {
+	case dot >= 0 && comma >= 0 && comma > dot:
+		return strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+	case dot >= 0 && comma >= 0:
+		return strings.ReplaceAll(s, ",", "")
+	case comma >= 0:
+		return strings.Replace(s, ",", ".", 1)
+	case strings.Count(s, ".") > 1:
+		return strings.ReplaceAll(s, ".", "")
+	}
+
+	return s
+}
+
+// roundRat arredonda r para um inteiro conforme mode.
+func roundRat(r *big.Rat, mode RoundingMode) Money {
+	num, den := r.Num(), r.Denom()
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return Money(quo.Int64())
+	}
+
+	away := false
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	default:
+		// Compara 2*|resto| com o denominador para saber se passou da metade.
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+
+		switch twice.Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || quo.Bit(0) == 1
+		}
+	}
+
+	if away {
+		quo.Add(quo, big.NewInt(int64(r.Sign())))
+	}
+
+	return Money(quo.Int64())
+}
@@ -0,0 +1,204 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{"1234.56", 123456, false},
+		{"1234,56", 123456, false},
+		{"1.234,56", 123456, false},
+		{"1,234.56", 123456, false},
+		{"R$ 1.234,56", 123456, false},
+		{"-R$ 0,50", -50, false},
+		{"R$ -0,50", -50, false},
+		{"1.234.567", 123456700, false},
+		{"10", 1000, false},
+		{"0.125", 13, false},
+		{"-0.125", -13, false},
+		{"0.124", 12, false},
+		{"", 0, false},
+		{"R$", 0, false},
+		{"1e2", 0, true},
+		{"1/2", 0, true},
+		{"+10", 0, true},
+		{"dez reais", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalido) {
+				t.Errorf("Parse(%q) erro = %v, esperado ErrInvalido", tt.in, err)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v, esperado %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFromFloatRounded(t *testing.T) {
+	tests := []struct {
+		in   float64
+		mode RoundingMode
+		want Money
+	}{
+		{2.675, RoundHalfUp, 268},
+		{2.665, RoundHalfUp, 267},
+		{-2.665, RoundHalfUp, -267},
+		{2.665, RoundHalfEven, 266},
+		{2.675, RoundHalfEven, 268},
+		{-2.665, RoundHalfEven, -266},
+		{2.679, RoundDown, 267},
+		{-2.679, RoundDown, -267},
+		{2.671, RoundUp, 268},
+		{-2.671, RoundUp, -268},
+		{2.67, RoundUp, 267},
+		{0.1 + 0.2, RoundHalfUp, 30},
+	}
+
+	for _, tt := range tests {
+		if got := FromFloatRounded(tt.in, tt.mode); got != tt.want {
+			t.Errorf("FromFloatRounded(%v, %d) = %d, esperado %d", tt.in, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestArredondamento(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"Percent(2)", New(1500, 0).Percent(2, RoundHalfUp), 3000},
+		{"Percent(0.5) HalfEven", New(0, 5).Percent(50, RoundHalfEven), 2},
+		{"Percent(0.5) HalfUp", New(0, 5).Percent(50, RoundHalfUp), 3},
+		{"MulRate(0.1)", New(0, 15).MulRate(0.1, RoundHalfUp), 2},
+		{"MulRate(0.1) Down", New(0, 15).MulRate(0.1, RoundDown), 1},
+		{"Mul(3)", New(1, 10).Mul(3), 330},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, esperado %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		in      Money
+		n       int64
+		mode    RoundingMode
+		want    Money
+		wantErr error
+	}{
+		{New(10, 0), 3, RoundHalfUp, 333, nil},
+		{New(10, 0), 3, RoundUp, 334, nil},
+		{New(0, 20), 8, RoundHalfEven, 2, nil},
+		{New(0, 20), 8, RoundHalfUp, 3, nil},
+		{New(10, 0), -3, RoundDown, -333, nil},
+		{New(-10, 0), -4, RoundHalfUp, 250, nil},
+		{New(10, 0), 0, RoundHalfUp, 0, ErrDivisaoPorZero},
+		{0, 0, RoundDown, 0, ErrDivisaoPorZero},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.in.Div(tt.n, tt.mode)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("%d.Div(%d, %d) = %d, %v, esperado %d, %v", tt.in, tt.n, tt.mode, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		in   Money
+		n    int
+		want []Money
+	}{
+		{New(10, 0), 3, []Money{334, 333, 333}},
+		{New(-10, 0), 3, []Money{-334, -333, -333}},
+		{New(0, 2), 3, []Money{1, 1, 0}},
+		{New(9, 0), 3, []Money{300, 300, 300}},
+		{New(1, 0), 0, nil},
+	}
+
+	for _, tt := range tests {
+		got := tt.in.Split(tt.n)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s.Split(%d) = %v, esperado %v", tt.in, tt.n, got, tt.want)
+		}
+
+		if tt.n > 0 && Sum(got...) != tt.in {
+			t.Errorf("%s.Split(%d) soma %s", tt.in, tt.n, Sum(got...))
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in      Money
+		str     string
+		decimal string
+	}{
+		{123456, "R$ 1.234,56", "1234.56"},
+		{-50, "-R$ 0,50", "-0.50"},
+		{0, "R$ 0,00", "0.00"},
+		{100000000, "R$ 1.000.000,00", "1000000.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.str {
+			t.Errorf("String(%d) = %s, esperado %s", int64(tt.in), got, tt.str)
+		}
+
+		if got := tt.in.Decimal(); got != tt.decimal {
+			t.Errorf("Decimal(%d) = %s, esperado %s", int64(tt.in), got, tt.decimal)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{`1234.56`, 123456, false},
+		{`1e2`, 10000, false},
+		{`0.125`, 13, false},
+		{`"1.234,56"`, 123456, false},
+		{`"R$ 10,00"`, 1000, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v, esperado %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Valor Money
+		Texto MoneyTexto
+	}{New(-1, -5), MoneyTexto(New(10, 0))})
+	if want := `{"Valor":-1.05,"Texto":"10.00"}`; err != nil || string(data) != want {
+		t.Errorf("Marshal = %s, %v, esperado %s", data, err, want)
+	}
+}
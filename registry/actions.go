@@ -0,0 +1,565 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/itispx/goimobiliar/session"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_anexo_adicionar_arquivo"
+	"github.com/itispx/goimobiliar/actions/cadastro_anexo_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_anexo_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_anexo_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_anexo_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_consultor_listar"
+	"github.com/itispx/goimobiliar/actions/cadastro_dadosconexao_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_dadosconexao_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_dadosconexao_excluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_dadosconexao_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_filial_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_filial_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_anexo_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_fornecedor_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_loja_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_loja_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_observacao_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_observacao_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_observacao_excluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_observacao_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_observacao_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar_vinculo"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_notificacao_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_notificacao_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_tarefa_alterar"
+	"github.com/itispx/goimobiliar/actions/cadastro_tarefa_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_tarefa_incluir"
+	"github.com/itispx/goimobiliar/actions/cadastro_tarefa_pesquisar"
+	"github.com/itispx/goimobiliar/actions/cadastro_taxa_consultar"
+	cadastro_tarefa_iss_consultar "github.com/itispx/goimobiliar/actions/cadastro_taxa_iss_consultar"
+	"github.com/itispx/goimobiliar/actions/cadastro_taxa_pesquisar"
+	"github.com/itispx/goimobiliar/actions/comerc_interessado_alterar"
+	"github.com/itispx/goimobiliar/actions/comerc_interessado_consultar"
+	"github.com/itispx/goimobiliar/actions/comerc_interessado_incluir"
+	"github.com/itispx/goimobiliar/actions/comerc_interessado_pesquisar"
+	"github.com/itispx/goimobiliar/actions/condom_condominio_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_condominio_pesquisar"
+	"github.com/itispx/goimobiliar/actions/condom_consultor_incluir"
+	"github.com/itispx/goimobiliar/actions/condom_economia_alterar"
+	"github.com/itispx/goimobiliar/actions/condom_economia_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_economia_incluir"
+	"github.com/itispx/goimobiliar/actions/condom_lancamento_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_lancamento_pesquisar"
+	"github.com/itispx/goimobiliar/actions/condom_lista_economias"
+	"github.com/itispx/goimobiliar/actions/condom_lista_inadimplencias"
+	"github.com/itispx/goimobiliar/actions/condom_pastadigital_consultar"
+	"github.com/itispx/goimobiliar/actions/condom_relatorio_extratocc_analitico"
+	"github.com/itispx/goimobiliar/actions/condom_relatorio_mensal"
+	"github.com/itispx/goimobiliar/actions/ctapag_administradora_incluir"
+	"github.com/itispx/goimobiliar/actions/ctapag_codbarras_consultar"
+	"github.com/itispx/goimobiliar/actions/ctapag_condominio_incluir"
+	"github.com/itispx/goimobiliar/actions/ctapag_condominio_notafiscal_importar"
+	"github.com/itispx/goimobiliar/actions/ctapag_imovel_incluir"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_adicionar_imagem"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_alterar"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_consultar"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_consultar_imagem"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_excluir"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_pesquisar"
+	"github.com/itispx/goimobiliar/actions/ctapag_lancamento_tornar_real"
+	"github.com/itispx/goimobiliar/actions/ctapag_proprietario_incluir"
+	"github.com/itispx/goimobiliar/actions/ctapag_relatorio_conferencia"
+	"github.com/itispx/goimobiliar/actions/ctapag_relatorio_slip"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_acordo_calcular"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_acordo_incluir"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_calcular_acresc_desc"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_cancelar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_condom_calcular"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_consultar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_inadimplencia_alterar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_inadimplente_2via"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_pdf_consultar"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_pesquisar_inadimplencias"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_pesquisar_naopagos"
+	"github.com/itispx/goimobiliar/actions/ctarec_boleto_quitar"
+	"github.com/itispx/goimobiliar/actions/ctarec_relatorio_slip"
+	"github.com/itispx/goimobiliar/actions/lanctocc_imovel_incluir"
+	"github.com/itispx/goimobiliar/actions/lanctocc_proprietario_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_consultor_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_adm_pesquisar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_contrato_imovel_pesquisar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_alterar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_imagens_incluir"
+	locacao_imovel_imagens_alterar "github.com/itispx/goimobiliar/actions/locacao_imovel_imagens_listar"
+	"github.com/itispx/goimobiliar/actions/locacao_imovel_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_lancto_automatico_adicionar_alterar"
+	"github.com/itispx/goimobiliar/actions/locacao_lancto_automatico_excluir"
+	"github.com/itispx/goimobiliar/actions/locacao_lancto_automatico_pesquisar"
+	"github.com/itispx/goimobiliar/actions/locacao_lancto_cond_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_lancto_cond_incluir"
+	"github.com/itispx/goimobiliar/actions/locacao_relatorio_demonstrativo_proprietario"
+	"github.com/itispx/goimobiliar/actions/locacao_relatorio_mensal"
+	"github.com/itispx/goimobiliar/actions/locacao_saldo_proprietario"
+	"github.com/itispx/goimobiliar/actions/locacao_seguro_alterar"
+	"github.com/itispx/goimobiliar/actions/locacao_seguro_consultar"
+	"github.com/itispx/goimobiliar/actions/locacao_seguro_incluir"
+	"github.com/itispx/goimobiliar/actions/notificacao_consultar"
+	"github.com/itispx/goimobiliar/actions/parametro_geral_consultar"
+	"github.com/itispx/goimobiliar/actions/tabela_consultar"
+)
+
+func init() {
+	add(cadastro_anexo_adicionar_arquivo.ACTION, "Adiciona o arquivo de um anexo.", cadastro_anexo_adicionar_arquivo.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_anexo_adicionar_arquivo.ActionInput) (*cadastro_anexo_adicionar_arquivo.RunOutput, error) {
+		return cadastro_anexo_adicionar_arquivo.RunContext(ctx, &cadastro_anexo_adicionar_arquivo.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_anexo_alterar.ACTION, "Altera um anexo.", cadastro_anexo_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_anexo_alterar.ActionInput) (*cadastro_anexo_alterar.RunOutput, error) {
+		return cadastro_anexo_alterar.RunContext(ctx, &cadastro_anexo_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_anexo_consultar.ACTION, "Consulta um anexo.", cadastro_anexo_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_anexo_consultar.ActionInput) (*cadastro_anexo_consultar.RunOutput, error) {
+		return cadastro_anexo_consultar.RunContext(ctx, &cadastro_anexo_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_anexo_incluir.ACTION, "Inclui um anexo.", cadastro_anexo_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_anexo_incluir.ActionInput) (*cadastro_anexo_incluir.RunOutput, error) {
+		return cadastro_anexo_incluir.RunContext(ctx, &cadastro_anexo_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_anexo_pesquisar.ACTION, "Pesquisa anexos.", cadastro_anexo_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_anexo_pesquisar.ActionInput) (*cadastro_anexo_pesquisar.RunOutput, error) {
+		return cadastro_anexo_pesquisar.RunContext(ctx, &cadastro_anexo_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_consultor_listar.ACTION, "Lista os consultores.", cadastro_consultor_listar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_consultor_listar.ActionInput) (*cadastro_consultor_listar.RunOutput, error) {
+		return cadastro_consultor_listar.RunContext(ctx, &cadastro_consultor_listar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_dadosconexao_alterar.ACTION, "Altera dados de conexão.", cadastro_dadosconexao_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_dadosconexao_alterar.ActionInput) (*cadastro_dadosconexao_alterar.RunOutput, error) {
+		return cadastro_dadosconexao_alterar.RunContext(ctx, &cadastro_dadosconexao_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_dadosconexao_consultar.ACTION, "Consulta dados de conexão.", cadastro_dadosconexao_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_dadosconexao_consultar.ActionInput) (*cadastro_dadosconexao_consultar.RunOutput, error) {
+		return cadastro_dadosconexao_consultar.RunContext(ctx, &cadastro_dadosconexao_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_dadosconexao_excluir.ACTION, "Exclui dados de conexão.", cadastro_dadosconexao_excluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_dadosconexao_excluir.ActionInput) (*cadastro_dadosconexao_excluir.RunOutput, error) {
+		return cadastro_dadosconexao_excluir.RunContext(ctx, &cadastro_dadosconexao_excluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_dadosconexao_incluir.ACTION, "Inclui dados de conexão.", cadastro_dadosconexao_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_dadosconexao_incluir.ActionInput) (*cadastro_dadosconexao_incluir.RunOutput, error) {
+		return cadastro_dadosconexao_incluir.RunContext(ctx, &cadastro_dadosconexao_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_filial_consultar.ACTION, "Consulta uma filial.", cadastro_filial_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_filial_consultar.ActionInput) (*cadastro_filial_consultar.RunOutput, error) {
+		return cadastro_filial_consultar.RunContext(ctx, &cadastro_filial_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_filial_pesquisar.ACTION, "Pesquisa filiais.", cadastro_filial_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_filial_pesquisar.ActionInput) (*cadastro_filial_pesquisar.RunOutput, error) {
+		return cadastro_filial_pesquisar.RunContext(ctx, &cadastro_filial_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_fornecedor_alterar.ACTION, "Altera um fornecedor.", cadastro_fornecedor_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_fornecedor_alterar.ActionInput) (*cadastro_fornecedor_alterar.RunOutput, error) {
+		return cadastro_fornecedor_alterar.RunContext(ctx, &cadastro_fornecedor_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_fornecedor_anexo_consultar.ACTION, "Consulta os anexos de um fornecedor.", cadastro_fornecedor_anexo_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_fornecedor_anexo_consultar.ActionInput) (*cadastro_fornecedor_anexo_consultar.RunOutput, error) {
+		return cadastro_fornecedor_anexo_consultar.RunContext(ctx, &cadastro_fornecedor_anexo_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_fornecedor_consultar.ACTION, "Consulta um fornecedor.", cadastro_fornecedor_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_fornecedor_consultar.ActionInput) (*cadastro_fornecedor_consultar.RunOutput, error) {
+		return cadastro_fornecedor_consultar.RunContext(ctx, &cadastro_fornecedor_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_fornecedor_incluir.ACTION, "Inclui um fornecedor.", cadastro_fornecedor_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_fornecedor_incluir.ActionInput) (*cadastro_fornecedor_incluir.RunOutput, error) {
+		return cadastro_fornecedor_incluir.RunContext(ctx, &cadastro_fornecedor_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_fornecedor_pesquisar.ACTION, "Pesquisa fornecedores.", cadastro_fornecedor_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_fornecedor_pesquisar.ActionInput) (*cadastro_fornecedor_pesquisar.RunOutput, error) {
+		return cadastro_fornecedor_pesquisar.RunContext(ctx, &cadastro_fornecedor_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_loja_consultar.ACTION, "Consulta uma loja/agência.", cadastro_loja_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_loja_consultar.ActionInput) (*cadastro_loja_consultar.RunOutput, error) {
+		return cadastro_loja_consultar.RunContext(ctx, &cadastro_loja_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_loja_pesquisar.ACTION, "Pesquisa lojas/agências.", cadastro_loja_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_loja_pesquisar.ActionInput) (*cadastro_loja_pesquisar.RunOutput, error) {
+		return cadastro_loja_pesquisar.RunContext(ctx, &cadastro_loja_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_observacao_alterar.ACTION, "Altera uma observação.", cadastro_observacao_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_observacao_alterar.ActionInput) (*cadastro_observacao_alterar.RunOutput, error) {
+		return cadastro_observacao_alterar.RunContext(ctx, &cadastro_observacao_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_observacao_consultar.ACTION, "Consulta uma observação.", cadastro_observacao_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_observacao_consultar.ActionInput) (*cadastro_observacao_consultar.RunOutput, error) {
+		return cadastro_observacao_consultar.RunContext(ctx, &cadastro_observacao_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_observacao_excluir.ACTION, "Exclui uma observação.", cadastro_observacao_excluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_observacao_excluir.ActionInput) (*cadastro_observacao_excluir.RunOutput, error) {
+		return cadastro_observacao_excluir.RunContext(ctx, &cadastro_observacao_excluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_observacao_incluir.ACTION, "Inclui uma observação.", cadastro_observacao_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_observacao_incluir.ActionInput) (*cadastro_observacao_incluir.RunOutput, error) {
+		return cadastro_observacao_incluir.RunContext(ctx, &cadastro_observacao_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_observacao_pesquisar.ACTION, "Pesquisa observações.", cadastro_observacao_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_observacao_pesquisar.ActionInput) (*cadastro_observacao_pesquisar.RunOutput, error) {
+		return cadastro_observacao_pesquisar.RunContext(ctx, &cadastro_observacao_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_alterar.ACTION, "Altera uma pessoa.", cadastro_pessoa_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_alterar.ActionInput) (*cadastro_pessoa_alterar.RunOutput, error) {
+		return cadastro_pessoa_alterar.RunContext(ctx, &cadastro_pessoa_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_consultar.ACTION, "Consulta uma pessoa.", cadastro_pessoa_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_consultar.ActionInput) (*cadastro_pessoa_consultar.RunOutput, error) {
+		return cadastro_pessoa_consultar.RunContext(ctx, &cadastro_pessoa_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_consultar_vinculo.ACTION, "Consulta os vínculos de uma pessoa.", cadastro_pessoa_consultar_vinculo.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_consultar_vinculo.ActionInput) (*cadastro_pessoa_consultar_vinculo.RunOutput, error) {
+		return cadastro_pessoa_consultar_vinculo.RunContext(ctx, &cadastro_pessoa_consultar_vinculo.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_incluir.ACTION, "Inclui uma pessoa.", cadastro_pessoa_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_incluir.ActionInput) (*cadastro_pessoa_incluir.RunOutput, error) {
+		return cadastro_pessoa_incluir.RunContext(ctx, &cadastro_pessoa_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_notificacao_alterar.ACTION, "Altera as notificações de uma pessoa.", cadastro_pessoa_notificacao_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_notificacao_alterar.ActionInput) (*cadastro_pessoa_notificacao_alterar.RunOutput, error) {
+		return cadastro_pessoa_notificacao_alterar.RunContext(ctx, &cadastro_pessoa_notificacao_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_notificacao_consultar.ACTION, "Consulta as notificações de uma pessoa.", cadastro_pessoa_notificacao_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_notificacao_consultar.ActionInput) (*cadastro_pessoa_notificacao_consultar.RunOutput, error) {
+		return cadastro_pessoa_notificacao_consultar.RunContext(ctx, &cadastro_pessoa_notificacao_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_pessoa_pesquisar.ACTION, "Pesquisa pessoas.", cadastro_pessoa_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_pessoa_pesquisar.ActionInput) (*cadastro_pessoa_pesquisar.RunOutput, error) {
+		return cadastro_pessoa_pesquisar.RunContext(ctx, &cadastro_pessoa_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_tarefa_alterar.ACTION, "Altera uma tarefa.", cadastro_tarefa_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_tarefa_alterar.ActionInput) (*cadastro_tarefa_alterar.RunOutput, error) {
+		return cadastro_tarefa_alterar.RunContext(ctx, &cadastro_tarefa_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_tarefa_consultar.ACTION, "Consulta uma tarefa.", cadastro_tarefa_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_tarefa_consultar.ActionInput) (*cadastro_tarefa_consultar.RunOutput, error) {
+		return cadastro_tarefa_consultar.RunContext(ctx, &cadastro_tarefa_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_tarefa_incluir.ACTION, "Inclui uma tarefa.", cadastro_tarefa_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_tarefa_incluir.ActionInput) (*cadastro_tarefa_incluir.RunOutput, error) {
+		return cadastro_tarefa_incluir.RunContext(ctx, &cadastro_tarefa_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_tarefa_pesquisar.ACTION, "Pesquisa tarefas.", cadastro_tarefa_pesquisar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_tarefa_pesquisar.ActionInput) (*cadastro_tarefa_pesquisar.RunOutput, error) {
+		return cadastro_tarefa_pesquisar.RunContext(ctx, &cadastro_tarefa_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_taxa_consultar.ACTION, "Consulta uma taxa.", cadastro_taxa_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_taxa_consultar.ActionInput) (*cadastro_taxa_consultar.RunOutput, error) {
+		return cadastro_taxa_consultar.RunContext(ctx, &cadastro_taxa_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_tarefa_iss_consultar.ACTION, "Consulta a alíquota de ISS de uma taxa.", cadastro_tarefa_iss_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *cadastro_tarefa_iss_consultar.ActionInput) (*cadastro_tarefa_iss_consultar.RunOutput, error) {
+		return cadastro_tarefa_iss_consultar.RunContext(ctx, &cadastro_tarefa_iss_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(cadastro_taxa_pesquisar.ACTION, "Pesquisa taxas.", cadastro_taxa_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *cadastro_taxa_pesquisar.ActionInput) (*cadastro_taxa_pesquisar.RunOutput, error) {
+		return cadastro_taxa_pesquisar.RunContext(ctx, &cadastro_taxa_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(comerc_interessado_alterar.ACTION, "Altera um interessado.", comerc_interessado_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *comerc_interessado_alterar.ActionInput) (*comerc_interessado_alterar.RunOutput, error) {
+		return comerc_interessado_alterar.RunContext(ctx, &comerc_interessado_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(comerc_interessado_consultar.ACTION, "Consulta um interessado.", comerc_interessado_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *comerc_interessado_consultar.ActionInput) (*comerc_interessado_consultar.RunOutput, error) {
+		return comerc_interessado_consultar.RunContext(ctx, &comerc_interessado_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(comerc_interessado_incluir.ACTION, "Inclui um interessado.", comerc_interessado_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *comerc_interessado_incluir.ActionInput) (*comerc_interessado_incluir.RunOutput, error) {
+		return comerc_interessado_incluir.RunContext(ctx, &comerc_interessado_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(comerc_interessado_pesquisar.ACTION, "Pesquisa interessados.", comerc_interessado_pesquisar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *comerc_interessado_pesquisar.ActionInput) (*comerc_interessado_pesquisar.RunOutput, error) {
+		return comerc_interessado_pesquisar.RunContext(ctx, &comerc_interessado_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_condominio_consultar.ACTION, "Consulta um condomínio.", condom_condominio_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_condominio_consultar.ActionInput) (*condom_condominio_consultar.RunOutput, error) {
+		return condom_condominio_consultar.RunContext(ctx, &condom_condominio_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_condominio_pesquisar.ACTION, "Pesquisa condomínios.", condom_condominio_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *condom_condominio_pesquisar.ActionInput) (*condom_condominio_pesquisar.RunOutput, error) {
+		return condom_condominio_pesquisar.RunContext(ctx, &condom_condominio_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_consultor_incluir.ACTION, "Inclui um consultor no condomínio.", condom_consultor_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_consultor_incluir.ActionInput) (*condom_consultor_incluir.RunOutput, error) {
+		return condom_consultor_incluir.RunContext(ctx, &condom_consultor_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_economia_alterar.ACTION, "Altera uma economia/unidade de condomínio.", condom_economia_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_economia_alterar.ActionInput) (*condom_economia_alterar.RunOutput, error) {
+		return condom_economia_alterar.RunContext(ctx, &condom_economia_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_economia_consultar.ACTION, "Consulta uma economia/unidade de condomínio.", condom_economia_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_economia_consultar.ActionInput) (*condom_economia_consultar.RunOutput, error) {
+		return condom_economia_consultar.RunContext(ctx, &condom_economia_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_economia_incluir.ACTION, "Inclui uma economia/unidade de condomínio.", condom_economia_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_economia_incluir.ActionInput) (*condom_economia_incluir.RunOutput, error) {
+		return condom_economia_incluir.RunContext(ctx, &condom_economia_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_lancamento_consultar.ACTION, "Consulta os lançamentos de um condomínio.", condom_lancamento_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_lancamento_consultar.ActionInput) (*condom_lancamento_consultar.RunOutput, error) {
+		return condom_lancamento_consultar.RunContext(ctx, &condom_lancamento_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_lancamento_pesquisar.ACTION, "Inclui um lançamento de condomínio.", condom_lancamento_pesquisar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_lancamento_pesquisar.ActionInput) (*condom_lancamento_pesquisar.RunOutput, error) {
+		return condom_lancamento_pesquisar.RunContext(ctx, &condom_lancamento_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_lista_economias.ACTION, "Lista as economias/unidades de um condomínio.", condom_lista_economias.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_lista_economias.ActionInput) (*condom_lista_economias.RunOutput, error) {
+		return condom_lista_economias.RunContext(ctx, &condom_lista_economias.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_lista_inadimplencias.ACTION, "Lista as inadimplências de um condomínio.", condom_lista_inadimplencias.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_lista_inadimplencias.ActionInput) (*condom_lista_inadimplencias.RunOutput, error) {
+		return condom_lista_inadimplencias.RunContext(ctx, &condom_lista_inadimplencias.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_pastadigital_consultar.ACTION, "Consulta a pasta digital de um condomínio.", condom_pastadigital_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_pastadigital_consultar.ActionInput) (*condom_pastadigital_consultar.RunOutput, error) {
+		return condom_pastadigital_consultar.RunContext(ctx, &condom_pastadigital_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_relatorio_extratocc_analitico.ACTION, "Relatório analítico do extrato de conta corrente do condomínio.", condom_relatorio_extratocc_analitico.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_relatorio_extratocc_analitico.ActionInput) (*condom_relatorio_extratocc_analitico.RunOutput, error) {
+		return condom_relatorio_extratocc_analitico.RunContext(ctx, &condom_relatorio_extratocc_analitico.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(condom_relatorio_mensal.ACTION, "Relatório mensal do condomínio.", condom_relatorio_mensal.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *condom_relatorio_mensal.ActionInput) (*condom_relatorio_mensal.RunOutput, error) {
+		return condom_relatorio_mensal.RunContext(ctx, &condom_relatorio_mensal.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_administradora_incluir.ACTION, "Inclui uma conta a pagar da administradora.", ctapag_administradora_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_administradora_incluir.ActionInput) (*ctapag_administradora_incluir.RunOutput, error) {
+		return ctapag_administradora_incluir.RunContext(ctx, &ctapag_administradora_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_codbarras_consultar.ACTION, "Consulta um código de barras de conta a pagar.", ctapag_codbarras_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_codbarras_consultar.ActionInput) (*ctapag_codbarras_consultar.RunOutput, error) {
+		return ctapag_codbarras_consultar.RunContext(ctx, &ctapag_codbarras_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_condominio_incluir.ACTION, "Inclui uma conta a pagar de condomínio.", ctapag_condominio_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_condominio_incluir.ActionInput) (*ctapag_condominio_incluir.RunOutput, error) {
+		return ctapag_condominio_incluir.RunContext(ctx, &ctapag_condominio_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_condominio_notafiscal_importar.ACTION, "Importa uma nota fiscal como conta a pagar de condomínio.", ctapag_condominio_notafiscal_importar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_condominio_notafiscal_importar.ActionInput) (*ctapag_condominio_notafiscal_importar.RunOutput, error) {
+		return ctapag_condominio_notafiscal_importar.RunContext(ctx, &ctapag_condominio_notafiscal_importar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_imovel_incluir.ACTION, "Inclui uma conta a pagar de imóvel.", ctapag_imovel_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_imovel_incluir.ActionInput) (*ctapag_imovel_incluir.RunOutput, error) {
+		return ctapag_imovel_incluir.RunContext(ctx, &ctapag_imovel_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_adicionar_imagem.ACTION, "Adiciona uma imagem a um lançamento de contas a pagar.", ctapag_lancamento_adicionar_imagem.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_adicionar_imagem.ActionInput) (*ctapag_lancamento_adicionar_imagem.RunOutput, error) {
+		return ctapag_lancamento_adicionar_imagem.RunContext(ctx, &ctapag_lancamento_adicionar_imagem.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_alterar.ACTION, "Altera um lançamento de contas a pagar.", ctapag_lancamento_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_alterar.ActionInput) (*ctapag_lancamento_alterar.RunOutput, error) {
+		return ctapag_lancamento_alterar.RunContext(ctx, &ctapag_lancamento_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_consultar.ACTION, "Consulta um lançamento de contas a pagar.", ctapag_lancamento_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_consultar.ActionInput) (*ctapag_lancamento_consultar.RunOutput, error) {
+		return ctapag_lancamento_consultar.RunContext(ctx, &ctapag_lancamento_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_consultar_imagem.ACTION, "Consulta a imagem de um lançamento de contas a pagar.", ctapag_lancamento_consultar_imagem.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_consultar_imagem.ActionInput) (*ctapag_lancamento_consultar_imagem.RunOutput, error) {
+		return ctapag_lancamento_consultar_imagem.RunContext(ctx, &ctapag_lancamento_consultar_imagem.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_excluir.ACTION, "Exclui um lançamento de contas a pagar.", ctapag_lancamento_excluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_excluir.ActionInput) (*ctapag_lancamento_excluir.RunOutput, error) {
+		return ctapag_lancamento_excluir.RunContext(ctx, &ctapag_lancamento_excluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_pesquisar.ACTION, "Pesquisa lançamentos de contas a pagar.", ctapag_lancamento_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_pesquisar.ActionInput) (*ctapag_lancamento_pesquisar.RunOutput, error) {
+		return ctapag_lancamento_pesquisar.RunContext(ctx, &ctapag_lancamento_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_lancamento_tornar_real.ACTION, "Torna real um lançamento previsto de contas a pagar.", ctapag_lancamento_tornar_real.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_lancamento_tornar_real.ActionInput) (*ctapag_lancamento_tornar_real.RunOutput, error) {
+		return ctapag_lancamento_tornar_real.RunContext(ctx, &ctapag_lancamento_tornar_real.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_proprietario_incluir.ACTION, "Inclui uma conta a pagar de proprietário.", ctapag_proprietario_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_proprietario_incluir.ActionInput) (*ctapag_proprietario_incluir.RunOutput, error) {
+		return ctapag_proprietario_incluir.RunContext(ctx, &ctapag_proprietario_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_relatorio_conferencia.ACTION, "Relatório de conferência de contas a pagar.", ctapag_relatorio_conferencia.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_relatorio_conferencia.ActionInput) (*ctapag_relatorio_conferencia.RunOutput, error) {
+		return ctapag_relatorio_conferencia.RunContext(ctx, &ctapag_relatorio_conferencia.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctapag_relatorio_slip.ACTION, "Relatório de slip de contas a pagar.", ctapag_relatorio_slip.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctapag_relatorio_slip.ActionInput) (*ctapag_relatorio_slip.RunOutput, error) {
+		return ctapag_relatorio_slip.RunContext(ctx, &ctapag_relatorio_slip.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_acordo_calcular.ACTION, "Calcula um acordo de boletos.", ctarec_boleto_acordo_calcular.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_acordo_calcular.ActionInput) (*ctarec_boleto_acordo_calcular.RunOutput, error) {
+		return ctarec_boleto_acordo_calcular.RunContext(ctx, &ctarec_boleto_acordo_calcular.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_acordo_incluir.ACTION, "Inclui um acordo de boletos.", ctarec_boleto_acordo_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_acordo_incluir.ActionInput) (*ctarec_boleto_acordo_incluir.RunOutput, error) {
+		return ctarec_boleto_acordo_incluir.RunContext(ctx, &ctarec_boleto_acordo_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_calcular_acresc_desc.ACTION, "Calcula acréscimos e descontos de um boleto.", ctarec_boleto_calcular_acresc_desc.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_calcular_acresc_desc.ActionInput) (*ctarec_boleto_calcular_acresc_desc.RunOutput, error) {
+		return ctarec_boleto_calcular_acresc_desc.RunContext(ctx, &ctarec_boleto_calcular_acresc_desc.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_cancelar.ACTION, "Cancela um boleto.", ctarec_boleto_cancelar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_cancelar.ActionInput) (*ctarec_boleto_cancelar.RunOutput, error) {
+		return ctarec_boleto_cancelar.RunContext(ctx, &ctarec_boleto_cancelar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_condom_calcular.ACTION, "Calcula um boleto de condomínio.", ctarec_boleto_condom_calcular.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_condom_calcular.ActionInput) (*ctarec_boleto_condom_calcular.RunOutput, error) {
+		return ctarec_boleto_condom_calcular.RunContext(ctx, &ctarec_boleto_condom_calcular.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_consultar.ACTION, "Consulta um boleto.", ctarec_boleto_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_consultar.ActionInput) (*ctarec_boleto_consultar.RunOutput, error) {
+		return ctarec_boleto_consultar.RunContext(ctx, &ctarec_boleto_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_inadimplencia_alterar.ACTION, "Altera a inadimplência de um boleto.", ctarec_boleto_inadimplencia_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_inadimplencia_alterar.ActionInput) (*ctarec_boleto_inadimplencia_alterar.RunOutput, error) {
+		return ctarec_boleto_inadimplencia_alterar.RunContext(ctx, &ctarec_boleto_inadimplencia_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_inadimplente_2via.ACTION, "Emite a 2ª via de um boleto inadimplente.", ctarec_boleto_inadimplente_2via.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_inadimplente_2via.ActionInput) (*ctarec_boleto_inadimplente_2via.RunOutput, error) {
+		return ctarec_boleto_inadimplente_2via.RunContext(ctx, &ctarec_boleto_inadimplente_2via.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_pdf_consultar.ACTION, "Consulta o PDF de um boleto.", ctarec_boleto_pdf_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_pdf_consultar.ActionInput) (*ctarec_boleto_pdf_consultar.RunOutput, error) {
+		return ctarec_boleto_pdf_consultar.RunContext(ctx, &ctarec_boleto_pdf_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_pesquisar_inadimplencias.ACTION, "Pesquisa boletos inadimplentes.", ctarec_boleto_pesquisar_inadimplencias.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_pesquisar_inadimplencias.ActionInput) (*ctarec_boleto_pesquisar_inadimplencias.RunOutput, error) {
+		return ctarec_boleto_pesquisar_inadimplencias.RunContext(ctx, &ctarec_boleto_pesquisar_inadimplencias.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_pesquisar_naopagos.ACTION, "Pesquisa os boletos não pagos de uma pessoa.", ctarec_boleto_pesquisar_naopagos.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_pesquisar_naopagos.ActionInput) (*ctarec_boleto_pesquisar_naopagos.RunOutput, error) {
+		return ctarec_boleto_pesquisar_naopagos.RunContext(ctx, &ctarec_boleto_pesquisar_naopagos.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_boleto_quitar.ACTION, "Quita um boleto.", ctarec_boleto_quitar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_boleto_quitar.ActionInput) (*ctarec_boleto_quitar.RunOutput, error) {
+		return ctarec_boleto_quitar.RunContext(ctx, &ctarec_boleto_quitar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(ctarec_relatorio_slip.ACTION, "Relatório de slip de contas a receber.", ctarec_relatorio_slip.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *ctarec_relatorio_slip.ActionInput) (*ctarec_relatorio_slip.RunOutput, error) {
+		return ctarec_relatorio_slip.RunContext(ctx, &ctarec_relatorio_slip.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(lanctocc_imovel_incluir.ACTION, "Inclui um lançamento na conta corrente de um imóvel.", lanctocc_imovel_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *lanctocc_imovel_incluir.ActionInput) (*lanctocc_imovel_incluir.RunOutput, error) {
+		return lanctocc_imovel_incluir.RunContext(ctx, &lanctocc_imovel_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(lanctocc_proprietario_incluir.ACTION, "Inclui um lançamento na conta corrente de um proprietário.", lanctocc_proprietario_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *lanctocc_proprietario_incluir.ActionInput) (*lanctocc_proprietario_incluir.RunOutput, error) {
+		return lanctocc_proprietario_incluir.RunContext(ctx, &lanctocc_proprietario_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_consultor_incluir.ACTION, "Inclui um consultor na locação.", locacao_consultor_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_consultor_incluir.ActionInput) (*locacao_consultor_incluir.RunOutput, error) {
+		return locacao_consultor_incluir.RunContext(ctx, &locacao_consultor_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_adm_consultar.ACTION, "Consulta um contrato de administração.", locacao_contrato_adm_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_contrato_adm_consultar.ActionInput) (*locacao_contrato_adm_consultar.RunOutput, error) {
+		return locacao_contrato_adm_consultar.RunContext(ctx, &locacao_contrato_adm_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_adm_incluir.ACTION, "Inclui um contrato de administração.", locacao_contrato_adm_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_contrato_adm_incluir.ActionInput) (*locacao_contrato_adm_incluir.RunOutput, error) {
+		return locacao_contrato_adm_incluir.RunContext(ctx, &locacao_contrato_adm_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_adm_pesquisar.ACTION, "Pesquisa contratos de administração.", locacao_contrato_adm_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *locacao_contrato_adm_pesquisar.ActionInput) (*locacao_contrato_adm_pesquisar.RunOutput, error) {
+		return locacao_contrato_adm_pesquisar.RunContext(ctx, &locacao_contrato_adm_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_imovel_consultar.ACTION, "Consulta um contrato de locação.", locacao_contrato_imovel_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_contrato_imovel_consultar.ActionInput) (*locacao_contrato_imovel_consultar.RunOutput, error) {
+		return locacao_contrato_imovel_consultar.RunContext(ctx, &locacao_contrato_imovel_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_imovel_incluir.ACTION, "Inclui um contrato de locação.", locacao_contrato_imovel_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_contrato_imovel_incluir.ActionInput) (*locacao_contrato_imovel_incluir.RunOutput, error) {
+		return locacao_contrato_imovel_incluir.RunContext(ctx, &locacao_contrato_imovel_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_contrato_imovel_pesquisar.ACTION, "Pesquisa contratos de locação.", locacao_contrato_imovel_pesquisar.IDEMPOTENT, true, func(ctx context.Context, sess *session.Session, input *locacao_contrato_imovel_pesquisar.ActionInput) (*locacao_contrato_imovel_pesquisar.RunOutput, error) {
+		return locacao_contrato_imovel_pesquisar.RunContext(ctx, &locacao_contrato_imovel_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_imovel_alterar.ACTION, "Altera um imóvel.", locacao_imovel_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_imovel_alterar.ActionInput) (*locacao_imovel_alterar.RunOutput, error) {
+		return locacao_imovel_alterar.RunContext(ctx, &locacao_imovel_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_imovel_consultar.ACTION, "Consulta um imóvel.", locacao_imovel_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_imovel_consultar.ActionInput) (*locacao_imovel_consultar.RunOutput, error) {
+		return locacao_imovel_consultar.RunContext(ctx, &locacao_imovel_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_imovel_imagens_incluir.ACTION, "Inclui imagens de um imóvel.", locacao_imovel_imagens_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_imovel_imagens_incluir.ActionInput) (*locacao_imovel_imagens_incluir.RunOutput, error) {
+		return locacao_imovel_imagens_incluir.RunContext(ctx, &locacao_imovel_imagens_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_imovel_imagens_alterar.ACTION, "Lista as imagens de um imóvel.", locacao_imovel_imagens_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_imovel_imagens_alterar.ActionInput) (*locacao_imovel_imagens_alterar.RunOutput, error) {
+		return locacao_imovel_imagens_alterar.RunContext(ctx, &locacao_imovel_imagens_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_imovel_incluir.ACTION, "Inclui um imóvel.", locacao_imovel_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_imovel_incluir.ActionInput) (*locacao_imovel_incluir.RunOutput, error) {
+		return locacao_imovel_incluir.RunContext(ctx, &locacao_imovel_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_lancto_automatico_adicionar_alterar.ACTION, "Adiciona ou altera um lançamento automático de locação.", locacao_lancto_automatico_adicionar_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_lancto_automatico_adicionar_alterar.ActionInput) (*locacao_lancto_automatico_adicionar_alterar.RunOutput, error) {
+		return locacao_lancto_automatico_adicionar_alterar.RunContext(ctx, &locacao_lancto_automatico_adicionar_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_lancto_automatico_excluir.ACTION, "Exclui um lançamento automático de locação.", locacao_lancto_automatico_excluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_lancto_automatico_excluir.ActionInput) (*locacao_lancto_automatico_excluir.RunOutput, error) {
+		return locacao_lancto_automatico_excluir.RunContext(ctx, &locacao_lancto_automatico_excluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_lancto_automatico_pesquisar.ACTION, "Pesquisa lançamentos automáticos de locação.", locacao_lancto_automatico_pesquisar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_lancto_automatico_pesquisar.ActionInput) (*locacao_lancto_automatico_pesquisar.RunOutput, error) {
+		return locacao_lancto_automatico_pesquisar.RunContext(ctx, &locacao_lancto_automatico_pesquisar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_lancto_cond_consultar.ACTION, "Consulta os lançamentos de condomínio de uma locação.", locacao_lancto_cond_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_lancto_cond_consultar.ActionInput) (*locacao_lancto_cond_consultar.RunOutput, error) {
+		return locacao_lancto_cond_consultar.RunContext(ctx, &locacao_lancto_cond_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_lancto_cond_incluir.ACTION, "Inclui um lançamento de condomínio em uma locação.", locacao_lancto_cond_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_lancto_cond_incluir.ActionInput) (*locacao_lancto_cond_incluir.RunOutput, error) {
+		return locacao_lancto_cond_incluir.RunContext(ctx, &locacao_lancto_cond_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_relatorio_demonstrativo_proprietario.ACTION, "Relatório demonstrativo do proprietário.", locacao_relatorio_demonstrativo_proprietario.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_relatorio_demonstrativo_proprietario.ActionInput) (*locacao_relatorio_demonstrativo_proprietario.RunOutput, error) {
+		return locacao_relatorio_demonstrativo_proprietario.RunContext(ctx, &locacao_relatorio_demonstrativo_proprietario.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_relatorio_mensal.ACTION, "Relatório mensal de locação.", locacao_relatorio_mensal.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_relatorio_mensal.ActionInput) (*locacao_relatorio_mensal.RunOutput, error) {
+		return locacao_relatorio_mensal.RunContext(ctx, &locacao_relatorio_mensal.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_saldo_proprietario.ACTION, "Consulta o saldo do proprietário.", locacao_saldo_proprietario.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_saldo_proprietario.ActionInput) (*locacao_saldo_proprietario.RunOutput, error) {
+		return locacao_saldo_proprietario.RunContext(ctx, &locacao_saldo_proprietario.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_seguro_alterar.ACTION, "Altera um seguro de locação.", locacao_seguro_alterar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_seguro_alterar.ActionInput) (*locacao_seguro_alterar.RunOutput, error) {
+		return locacao_seguro_alterar.RunContext(ctx, &locacao_seguro_alterar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_seguro_consultar.ACTION, "Consulta um seguro de locação.", locacao_seguro_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_seguro_consultar.ActionInput) (*locacao_seguro_consultar.RunOutput, error) {
+		return locacao_seguro_consultar.RunContext(ctx, &locacao_seguro_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(locacao_seguro_incluir.ACTION, "Inclui um seguro de locação.", locacao_seguro_incluir.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *locacao_seguro_incluir.ActionInput) (*locacao_seguro_incluir.RunOutput, error) {
+		return locacao_seguro_incluir.RunContext(ctx, &locacao_seguro_incluir.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(notificacao_consultar.ACTION, "Consulta notificações.", notificacao_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *notificacao_consultar.ActionInput) (*notificacao_consultar.RunOutput, error) {
+		return notificacao_consultar.RunContext(ctx, &notificacao_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(parametro_geral_consultar.ACTION, "Consulta os parâmetros gerais.", parametro_geral_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *parametro_geral_consultar.ActionInput) (*parametro_geral_consultar.RunOutput, error) {
+		return parametro_geral_consultar.RunContext(ctx, &parametro_geral_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+
+	add(tabela_consultar.ACTION, "Consulta uma tabela auxiliar.", tabela_consultar.IDEMPOTENT, false, func(ctx context.Context, sess *session.Session, input *tabela_consultar.ActionInput) (*tabela_consultar.RunOutput, error) {
+		return tabela_consultar.RunContext(ctx, &tabela_consultar.RunInput{Session: sess, ActionInput: input})
+	})
+}
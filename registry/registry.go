@@ -0,0 +1,105 @@
+// Package registry indexa as actions pelo nome, permitindo executá-las a
+// partir do JSON de entrada sem conhecer o pacote de cada uma, por exemplo em
+// CLIs, gateways e execuções em lote.
+//
+// LOGIN e LOGOUT não fazem parte do registro: as sessões são abertas e
+// encerradas pelo pacote session.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/itispx/goimobiliar/consts"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/session"
+)
+
+// ErrActionDesconhecida é retornado quando o nome não está registrado.
+var ErrActionDesconhecida = errors.New("imobiliar: action desconhecida")
+
+// Action descreve uma action registrada.
+type Action struct {
+	Name        string       // Nome da action, como em ACTION.
+	Description string       // Descrição curta da action.
+	Input       reflect.Type // Tipo ActionInput do pacote da action.
+	Output      reflect.Type // Tipo RunOutput do pacote da action.
+	ReadOnly    bool         // A action apenas consulta dados e pode ser repetida (IDEMPOTENT).
+	Paging      bool         // A action aceita QtdeLinhas e ProximasLinhas e tem a função All.
+
+	invoke func(ctx context.Context, sess *session.Session, input json.RawMessage) (any, error)
+}
+
+// Invoke executa a action com a entrada em JSON e retorna a saída em JSON.
+// Uma entrada vazia equivale a um ActionInput sem campos preenchidos.
+func (a *Action) Invoke(ctx context.Context, sess *session.Session, input json.RawMessage) (json.RawMessage, error) {
+	output, err := a.invoke(ctx, sess, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(output)
+}
+
+var actions = map[string]*Action{}
+
+func add[In, Out any](name, description string, readOnly, paging bool, run consts.RunFunc[*In, *Out]) {
+	actions[name] = &Action{
+		Name:        name,
+		Description: description,
+		Input:       reflect.TypeFor[In](),
+		Output:      reflect.TypeFor[Out](),
+		ReadOnly:    readOnly,
+		Paging:      paging,
+		invoke: func(ctx context.Context, sess *session.Session, input json.RawMessage) (any, error) {
+			actionInput := new(In)
+			if len(input) > 0 {
+				if err := json.Unmarshal(input, actionInput); err != nil {
+					return nil, fmt.Errorf("imobiliar: entrada inválida para %s: %w", name, err)
+				}
+			}
+
+			return run(ctx, sess, actionInput)
+		},
+	}
+}
+
+// Get retorna a action registrada com o nome informado.
+func Get(name string) (*Action, bool) {
+	action, ok := actions[name]
+
+	return action, ok
+}
+
+// Actions retorna todas as actions registradas, ordenadas pelo nome.
+func Actions() []*Action {
+	list := make([]*Action, 0, len(actions))
+	for _, action := range actions {
+		list = append(list, action)
+	}
+
+	slices.SortFunc(list, func(a, b *Action) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return list
+}
+
+// Invoke executa a action pelo nome. Veja Action.Invoke.
+func Invoke(ctx context.Context, sess *session.Session, name string, input json.RawMessage) (json.RawMessage, error) {
+	action, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrActionDesconhecida, name)
+	}
+
+	if sess == nil {
+		return nil, erros.ErrBaseInvalida
+	}
+
+	return action.Invoke(ctx, sess, input)
+}
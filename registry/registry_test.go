@@ -0,0 +1,205 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/itispx/goimobiliar/actions/cadastro_pessoa_consultar"
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/registry"
+)
+
+// actionPackage é o que o registro deve refletir de cada pacote em actions/.
+type actionPackage struct {
+	dir    string
+	action string // Valor de ACTION.
+	paging bool   // O pacote define a função All.
+}
+
+// actionPackages lê os pacotes em actions/, exceto LOGIN e LOGOUT, que não
+// fazem parte do registro.
+func actionPackages(t *testing.T) map[string]actionPackage {
+	t.Helper()
+
+	dirs, err := os.ReadDir(filepath.Join("..", "actions"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packages := map[string]actionPackage{}
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == "login" || dir.Name() == "logout" {
+			continue
+		}
+
+		pkg := actionPackage{dir: dir.Name()}
+
+		files, err := filepath.Glob(filepath.Join("..", "actions", dir.Name(), "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, path := range files {
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, decl := range file.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if decl.Recv == nil && decl.Name.Name == "All" {
+						pkg.paging = true
+					}
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						value, ok := spec.(*ast.ValueSpec)
+						if !ok || len(value.Names) != 1 || value.Names[0].Name != "ACTION" || len(value.Values) != 1 {
+							continue
+						}
+
+						if lit, ok := value.Values[0].(*ast.BasicLit); ok {
+							pkg.action, _ = strconv.Unquote(lit.Value)
+						}
+					}
+				}
+			}
+		}
+
+		if pkg.action == "" {
+			t.Errorf("%s: ACTION não encontrada", pkg.dir)
+			continue
+		}
+
+		packages[pkg.action] = pkg
+	}
+
+	return packages
+}
+
+func TestActions(t *testing.T) {
+	packages := actionPackages(t)
+	actions := registry.Actions()
+
+	if len(actions) != len(packages) {
+		t.Errorf("%d actions registradas, %d pacotes em actions/", len(actions), len(packages))
+	}
+
+	registered := map[string]bool{}
+	for _, action := range actions {
+		registered[action.Name] = true
+
+		pkg, ok := packages[action.Name]
+		if !ok {
+			t.Errorf("%s: registrada sem pacote correspondente", action.Name)
+			continue
+		}
+
+		if action.Paging != pkg.paging {
+			t.Errorf("%s: Paging = %v, mas %s define All = %v", action.Name, action.Paging, pkg.dir, pkg.paging)
+		}
+
+		if action.Description == "" || action.Input == nil || action.Output == nil {
+			t.Errorf("%s: registro incompleto: %+v", action.Name, action)
+		}
+	}
+
+	for name, pkg := range packages {
+		if !registered[name] {
+			t.Errorf("%s: pacote %s não registrado", name, pkg.dir)
+		}
+	}
+
+	if !slices.IsSortedFunc(actions, func(a, b *registry.Action) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Error("Actions não está ordenada pelo nome")
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	srv.Handle(cadastro_pessoa_consultar.ACTION, func(req *imobtest.Request) (any, error) {
+		var body cadastro_pessoa_consultar.ActionInput
+		if err := req.Decode(&body); err != nil {
+			return nil, err
+		}
+
+		return map[string]any{"CodPessoa": *body.CodPessoa, "Nome": "Fulano", "CpfCnpj": 1234567890}, nil
+	})
+
+	sess, err := srv.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.EndSession()
+
+	tests := []struct {
+		name    string
+		action  string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"ida e volta", cadastro_pessoa_consultar.ACTION, `{"CodPessoa": 7}`, `{"CodPessoa":7,"Nome":"Fulano","CpfCnpj":1234567890}`, nil},
+		{"campo obrigatório", cadastro_pessoa_consultar.ACTION, ``, ``, erros.ErrValidacao},
+		{"action desconhecida", "NAO_EXISTE", `{}`, ``, registry.ErrActionDesconhecida},
+	}
+
+	for _, tt := range tests {
+		got, err := registry.Invoke(context.Background(), sess, tt.action, json.RawMessage(tt.input))
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: erro = %v, esperado %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if canonical(t, string(got)) != canonical(t, tt.want) {
+			t.Errorf("%s: saída = %s, esperado %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := registry.Invoke(context.Background(), sess, cadastro_pessoa_consultar.ACTION, json.RawMessage(`{"CodPessoa": "sete"}`)); err == nil {
+		t.Error("entrada com tipo errado aceita")
+	}
+
+	if _, err := registry.Invoke(context.Background(), nil, cadastro_pessoa_consultar.ACTION, nil); !errors.Is(err, erros.ErrBaseInvalida) {
+		t.Errorf("sem sessão: erro = %v, esperado ErrBaseInvalida", err)
+	}
+
+	// Apenas a entrada válida chegou ao servidor.
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("%d requisições, esperado LOGIN e uma consulta", got)
+	}
+}
+
+// canonical reordena as chaves do JSON, para comparar saídas.
+func canonical(t *testing.T, s string) string {
+	t.Helper()
+
+	var value any
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		t.Fatalf("JSON inválido %s: %v", s, err)
+	}
+
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
@@ -0,0 +1,323 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/itispx/goimobiliar/transport"
+)
+
+const DefaultPoolIdleTTL = 5 * time.Minute
+
+var ErrPoolFechado = errors.New("pool de sessões fechado")
+
+// ErrSessaoDesconhecida é retornado por Discard para sessões que não foram
+// obtidas com Get do mesmo pool, ou que já foram devolvidas.
+var ErrSessaoDesconhecida = errors.New("sessão não emprestada por este pool")
+
+// Pool reaproveita sessões por (Endpoint, ImobId, UserId), respeitando o
+// MaxSessions retornado no LOGIN. Quando o limite é atingido, Get aguarda até
+// que uma sessão seja devolvida com Put ou descartada com Discard.
+type Pool struct {
+	idleTTL     time.Duration
+	maxSessions int
+	client      *transport.Client
+
+	mu       sync.Mutex
+	groups   map[poolKey]*poolGroup
+	borrowed map[*Session]*poolGroup // Sessões entregues por Get e ainda não devolvidas.
+	closed   bool
+	done     chan struct{}
+}
+
+type PoolInput struct {
+	IdleTTL     time.Duration     // Tempo ocioso até a sessão ser encerrada. Valor default é DefaultPoolIdleTTL.
+	MaxSessions int               // Limite adicional de sessões por usuário. Se 0, vale apenas o MaxSessions do servidor.
+	Client      *transport.Client // Cliente usado quando NewInput.Client não é informado.
+}
+
+type poolKey struct {
+	endpoint string
+	imobId   string
+	userId   string
+	userPass string // Hash MD5 da senha: uma senha errada não recebe sessões abertas com a correta.
+}
+
+type poolGroup struct {
+	limit   int // MaxSessions do servidor. Até o primeiro login, apenas uma sessão é aberta.
+	open    int // Sessões abertas, ociosas ou em uso, incluindo logins em andamento.
+	idle    []*poolIdleSession
+	waiters []chan struct{}
+}
+
+type poolIdleSession struct {
+	sess  *Session
+	since time.Time
+}
+
+func NewPool(input *PoolInput) *Pool {
+	if input == nil {
+		input = &PoolInput{}
+	}
+
+	idleTTL := input.IdleTTL
+	if idleTTL <= 0 {
+		idleTTL = DefaultPoolIdleTTL
+	}
+
+	p := &Pool{
+		idleTTL:     idleTTL,
+		maxSessions: input.MaxSessions,
+		client:      input.Client,
+		groups:      map[poolKey]*poolGroup{},
+		borrowed:    map[*Session]*poolGroup{},
+		done:        make(chan struct{}),
+	}
+
+	go p.janitor()
+
+	return p
+}
+
+// Get retorna uma sessão ociosa ou abre uma nova, se o limite permitir.
+// A sessão deve ser devolvida com Put ao fim do uso.
+func (p *Pool) Get(ctx context.Context, input *NewInput) (*Session, error) {
+	key := poolKey{endpoint: input.Endpoint, imobId: input.ImobId, userId: input.UserId, userPass: hashPass(input.UserPass)}
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolFechado
+		}
+
+		g := p.group(key)
+
+		if n := len(g.idle); n > 0 {
+			idle := g.idle[n-1]
+			g.idle = g.idle[:n-1]
+			p.borrowed[idle.sess] = g
+			p.mu.Unlock()
+			return idle.sess, nil
+		}
+
+		if g.open < p.limit(g) {
+			g.open++
+			p.mu.Unlock()
+			return p.open(ctx, g, input)
+		}
+
+		wait := make(chan struct{})
+		g.waiters = append(g.waiters, wait)
+		p.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			p.mu.Lock()
+			if !g.removeWaiter(wait) {
+				// Já foi notificado: repassa a vez para o próximo da fila.
+				g.notify()
+			}
+			p.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (p *Pool) open(ctx context.Context, g *poolGroup, input *NewInput) (*Session, error) {
+	newInput := *input
+	if newInput.Client == nil {
+		newInput.Client = p.client
+	}
+
+	sess, err := NewSessionContext(ctx, &newInput)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		g.open--
+		g.notify()
+		return nil, err
+	}
+
+	if sess.MaxSessions > 0 && sess.MaxSessions != g.limit {
+		g.limit = sess.MaxSessions
+		g.notifyAll()
+	}
+
+	p.borrowed[sess] = g
+
+	return sess, nil
+}
+
+// Put devolve uma sessão obtida com Get para reuso. Sessões que não foram
+// obtidas com Get deste pool, ou que já foram devolvidas, são ignoradas.
+func (p *Pool) Put(sess *Session) {
+	p.mu.Lock()
+	g, ok := p.borrowed[sess]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.borrowed, sess)
+
+	if p.closed {
+		g.open--
+		p.mu.Unlock()
+		sess.EndSession()
+		return
+	}
+
+	g.idle = append(g.idle, &poolIdleSession{sess: sess, since: time.Now()})
+	g.notify()
+	p.mu.Unlock()
+}
+
+// Discard encerra uma sessão obtida com Get e libera a vaga no pool.
+// Deve ser usado quando a sessão não pode mais ser reaproveitada.
+// Sessões que não foram obtidas com Get deste pool retornam
+// ErrSessaoDesconhecida e não são encerradas.
+func (p *Pool) Discard(sess *Session) error {
+	if sess == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	g, ok := p.borrowed[sess]
+	if !ok {
+		p.mu.Unlock()
+		return ErrSessaoDesconhecida
+	}
+	delete(p.borrowed, sess)
+
+	g.open--
+	g.notify()
+	p.mu.Unlock()
+
+	return sess.EndSession()
+}
+
+// Close encerra todas as sessões ociosas. Sessões em uso são encerradas ao
+// serem devolvidas com Put.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+
+	p.closed = true
+	close(p.done)
+
+	var sessions []*Session
+	for _, g := range p.groups {
+		for _, idle := range g.idle {
+			sessions = append(sessions, idle.sess)
+		}
+		g.open -= len(g.idle)
+		g.idle = nil
+		g.notifyAll()
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for _, sess := range sessions {
+		if err := sess.EndSession(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *Pool) janitor() {
+	ticker := time.NewTicker(max(p.idleTTL/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case now := <-ticker.C:
+			p.evict(now)
+		}
+	}
+}
+
+func (p *Pool) evict(now time.Time) {
+	var expired []*Session
+
+	p.mu.Lock()
+	for _, g := range p.groups {
+		kept := g.idle[:0]
+		for _, idle := range g.idle {
+			if now.Sub(idle.since) >= p.idleTTL {
+				expired = append(expired, idle.sess)
+				g.open--
+				g.notify()
+			} else {
+				kept = append(kept, idle)
+			}
+		}
+		g.idle = kept
+	}
+	p.mu.Unlock()
+
+	for _, sess := range expired {
+		sess.EndSession()
+	}
+}
+
+func (p *Pool) group(key poolKey) *poolGroup {
+	g, ok := p.groups[key]
+	if !ok {
+		g = &poolGroup{}
+		p.groups[key] = g
+	}
+
+	return g
+}
+
+func (p *Pool) limit(g *poolGroup) int {
+	limit := g.limit
+	if limit <= 0 {
+		limit = 1
+	}
+
+	if p.maxSessions > 0 && p.maxSessions < limit {
+		limit = p.maxSessions
+	}
+
+	return limit
+}
+
+func (g *poolGroup) notify() {
+	if len(g.waiters) == 0 {
+		return
+	}
+
+	close(g.waiters[0])
+	g.waiters = g.waiters[1:]
+}
+
+func (g *poolGroup) notifyAll() {
+	for _, wait := range g.waiters {
+		close(wait)
+	}
+	g.waiters = nil
+}
+
+func (g *poolGroup) removeWaiter(wait chan struct{}) bool {
+	for i, w := range g.waiters {
+		if w == wait {
+			g.waiters = append(g.waiters[:i], g.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,317 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/imobtest"
+	"github.com/itispx/goimobiliar/session"
+)
+
+func logins(srv *imobtest.Server) int {
+	n := 0
+	for _, req := range srv.Requests() {
+		if req.Action == "LOGIN" {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestPoolMaxSessions(t *testing.T) {
+	tests := []struct {
+		name       string
+		server     int // MaxSessions retornado no LOGIN.
+		pool       int // PoolInput.MaxSessions.
+		wantLimite int
+	}{
+		{"limite do servidor", 3, 0, 3},
+		{"limite do pool menor", 3, 2, 2},
+		{"limite do pool maior", 2, 4, 2},
+		{"uma sessão", 1, 0, 1},
+	}
+
+	for _, tt := range tests {
+		srv := imobtest.New(&imobtest.NewInput{MaxSessions: tt.server})
+		pool := session.NewPool(&session.PoolInput{MaxSessions: tt.pool})
+
+		var inUse, maxInUse atomic.Int32
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				sess, err := pool.Get(context.Background(), srv.NewSessionInput())
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				n := inUse.Add(1)
+				for {
+					m := maxInUse.Load()
+					if n <= m || maxInUse.CompareAndSwap(m, n) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				inUse.Add(-1)
+				pool.Put(sess)
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Errorf("%s: Get: %v", tt.name, err)
+		}
+
+		if got := int(maxInUse.Load()); got > tt.wantLimite {
+			t.Errorf("%s: %d sessões em uso ao mesmo tempo, limite %d", tt.name, got, tt.wantLimite)
+		}
+
+		if got := logins(srv); got > tt.wantLimite {
+			t.Errorf("%s: %d LOGINs, esperado no máximo %d", tt.name, got, tt.wantLimite)
+		}
+
+		if err := pool.Close(); err != nil {
+			t.Errorf("%s: Close: %v", tt.name, err)
+		}
+
+		if got := srv.Sessions(); got != 0 {
+			t.Errorf("%s: %d sessões abertas após Close", tt.name, got)
+		}
+
+		srv.Close()
+	}
+}
+
+func TestPoolReuso(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	pool := session.NewPool(nil)
+	defer pool.Close()
+
+	first, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Put(first)
+
+	second, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(second)
+
+	if second != first || logins(srv) != 1 {
+		t.Errorf("sessão não reaproveitada: %d LOGINs", logins(srv))
+	}
+
+	// Uma senha errada não recebe a sessão aberta com a correta, nem ocupa vaga.
+	input := srv.NewSessionInput()
+	input.UserPass = "ERRADA"
+	if _, err := pool.Get(context.Background(), input); !errors.Is(err, erros.ErrCredenciais) {
+		t.Errorf("Get com senha errada: erro = %v, esperado ErrCredenciais", err)
+	}
+}
+
+func TestPoolGetCancelado(t *testing.T) {
+	srv := imobtest.New(&imobtest.NewInput{MaxSessions: 1})
+	defer srv.Close()
+
+	pool := session.NewPool(nil)
+	defer pool.Close()
+
+	sess, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := pool.Get(ctx, srv.NewSessionInput()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get com o limite atingido: erro = %v, esperado context.DeadlineExceeded", err)
+	}
+
+	// O Get cancelado sai da fila: o próximo recebe a sessão devolvida.
+	got := make(chan *session.Session)
+	go func() {
+		next, err := pool.Get(context.Background(), srv.NewSessionInput())
+		if err != nil {
+			t.Error(err)
+		}
+		got <- next
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pool.Put(sess)
+
+	select {
+	case next := <-got:
+		if next != sess {
+			t.Error("Get em espera não recebeu a sessão devolvida")
+		}
+		pool.Put(next)
+	case <-time.After(time.Second):
+		t.Fatal("Get em espera não foi liberado pelo Put")
+	}
+
+	if got := logins(srv); got != 1 {
+		t.Errorf("%d LOGINs, esperado 1", got)
+	}
+}
+
+func TestPoolIdleTTL(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	pool := session.NewPool(&session.PoolInput{IdleTTL: 10 * time.Millisecond})
+	defer pool.Close()
+
+	sess, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Put(sess)
+
+	deadline := time.Now().Add(time.Second)
+	for srv.Sessions() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := srv.Sessions(); got != 0 {
+		t.Fatalf("%d sessões abertas após IdleTTL, esperado 0", got)
+	}
+
+	next, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(next)
+
+	if next == sess || logins(srv) != 2 {
+		t.Errorf("sessão encerrada foi reaproveitada: %d LOGINs", logins(srv))
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	pool := session.NewPool(&session.PoolInput{MaxSessions: 2})
+
+	idle, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	borrowed, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool.Put(idle)
+
+	if err := pool.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Apenas a sessão ociosa é encerrada; a emprestada continua em uso.
+	if got := srv.Sessions(); got != 1 {
+		t.Errorf("%d sessões abertas após Close, esperado 1", got)
+	}
+
+	if _, err := pool.Get(context.Background(), srv.NewSessionInput()); !errors.Is(err, session.ErrPoolFechado) {
+		t.Errorf("Get após Close: erro = %v, esperado ErrPoolFechado", err)
+	}
+
+	pool.Put(borrowed)
+
+	if got := srv.Sessions(); got != 0 {
+		t.Errorf("%d sessões abertas após Put no pool fechado, esperado 0", got)
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Errorf("segundo Close: %v", err)
+	}
+}
+
+func TestPoolDiscard(t *testing.T) {
+	srv := imobtest.New(nil)
+	defer srv.Close()
+
+	pool := session.NewPool(&session.PoolInput{MaxSessions: 1})
+	defer pool.Close()
+
+	sess, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Discard(sess); err != nil {
+		t.Fatalf("Discard: %v", err)
+	}
+
+	// A vaga liberada permite um novo LOGIN.
+	next, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next == sess || logins(srv) != 2 || srv.Sessions() != 1 {
+		t.Errorf("após Discard: %d LOGINs, %d sessões abertas", logins(srv), srv.Sessions())
+	}
+
+	// Sessões que o pool não emprestou, ou já devolvidas, não alteram a contagem.
+	outside, err := srv.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outside.EndSession()
+
+	tests := []struct {
+		name string
+		sess *session.Session
+	}{
+		{"sessão de fora do pool", outside},
+		{"sessão já descartada", sess},
+	}
+
+	for _, tt := range tests {
+		if err := pool.Discard(tt.sess); !errors.Is(err, session.ErrSessaoDesconhecida) {
+			t.Errorf("%s: Discard erro = %v, esperado ErrSessaoDesconhecida", tt.name, err)
+		}
+
+		pool.Put(tt.sess)
+	}
+
+	pool.Put(next)
+
+	again, err := pool.Get(context.Background(), srv.NewSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(again)
+
+	if again != next {
+		t.Error("Get não retornou a única sessão ociosa do pool")
+	}
+
+	if srv.Sessions() != 2 {
+		t.Errorf("%d sessões abertas, esperado 2", srv.Sessions())
+	}
+}
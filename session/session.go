@@ -1,13 +1,17 @@
 package session
 
 import (
+	"context"
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/itispx/goimobiliar/actions/login"
 	"github.com/itispx/goimobiliar/actions/logout"
 	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/transport"
 )
 
 type Session struct {
@@ -25,64 +29,173 @@ type Session struct {
 	Uf             string `json:"uf,omitempty"`
 	MaxSessions    int    `json:"maxSessions,omitempty"`
 	ServerDateTime string `json:"serverDateTime,omitempty"`
+
+	Client *transport.Client `json:"-"` // Cliente usado por todas as actions desta sessão.
+
+	// TruncateText faz as actions truncarem os textos acima do tamanho
+	// documentado, em vez de rejeitá-los antes do envio.
+	TruncateText bool `json:"-"`
+
+	mu             sync.RWMutex
+	userId         string
+	userPass       string // Hash MD5 da senha, como enviado no LOGIN.
+	disableRelogin bool
 }
 
 type NewInput struct {
-	Endpoint string
-	ImobId   string
-	UserId   string
-	UserPass string
+	Endpoint       string
+	ImobId         string
+	UserId         string
+	UserPass       string
+	Client         *transport.Client // Opcional. Valor default é transport.DefaultClient.
+	DisableRelogin bool              // Desativa o novo login automático quando a sessão expira.
+	TruncateText   bool              // Trunca textos longos em vez de rejeitá-los. Veja Session.TruncateText.
 }
 
 func NewSession(input *NewInput) (*Session, error) {
+	return NewSessionContext(context.Background(), input)
+}
+
+func NewSessionContext(ctx context.Context, input *NewInput) (*Session, error) {
 	if input.UserId == "" {
 		return nil, erros.ErrCampoVazio("userId")
 	} else if input.UserPass == "" {
 		return nil, erros.ErrCampoVazio("userPass")
 	}
 
-	password := fmt.Sprintf("%x", md5.Sum([]byte(strings.ToUpper(input.UserPass))))
+	sess := &Session{
+		Endpoint:       input.Endpoint,
+		ImobId:         input.ImobId,
+		Client:         input.Client,
+		TruncateText:   input.TruncateText,
+		userId:         input.UserId,
+		userPass:       hashPass(input.UserPass),
+		disableRelogin: input.DisableRelogin,
+	}
+
+	if err := sess.login(ctx); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
 
-	loginResponse, err := login.Run(&login.RunInput{
-		Endpoint: input.Endpoint,
+// login autentica no webservice e preenche a sessão com os dados retornados.
+// Deve ser chamado com s.mu travado para escrita, exceto na criação da sessão.
+func (s *Session) login(ctx context.Context) error {
+	loginResponse, err := login.RunContext(ctx, &login.RunInput{
+		Endpoint: s.Endpoint,
+		Client:   s.Client,
 		ActionInput: &login.ActionInput{
-			UserId:   &input.UserId,
-			UserPass: &password,
-			ImobId:   &input.ImobId,
+			UserId:   &s.userId,
+			UserPass: &s.userPass,
+			ImobId:   &s.ImobId,
 		},
 	})
 	if err != nil {
+		return err
+	}
+
+	s.SessionId = loginResponse.Header.SessionId
+	s.NomeImob = *loginResponse.Body.NomeImob
+	s.UsuarioId = *loginResponse.Body.UsuarioId
+	s.Nome = *loginResponse.Body.Nome
+	s.Versao = *loginResponse.Body.Versao
+	s.ClientIP = *loginResponse.Body.ClientIP
+	s.CodFilial = *loginResponse.Body.CodFilial
+	s.NomeFilial = *loginResponse.Body.NomeFilial
+	s.Cidade = *loginResponse.Body.Cidade
+	s.Uf = *loginResponse.Body.Uf
+	s.MaxSessions = *loginResponse.Body.MaxSessions
+	s.ServerDateTime = *loginResponse.Body.ServerDateTime
+
+	return nil
+}
+
+// Request é o envelope de uma action. O SessionId é preenchido pela sessão
+// a cada envio, permitindo reenviar a requisição após um novo login.
+type Request interface {
+	SetSessionId(sessionId string)
+}
+
+type SendInput struct {
+	Action     string
+	Idempotent bool
+	Request    Request
+}
+
+// Send envia a requisição de uma action usando esta sessão. Se o servidor
+// responder que a sessão expirou, um novo login é feito e a requisição é
+// reenviada uma única vez.
+func (s *Session) Send(ctx context.Context, input *SendInput) ([]byte, error) {
+	sessionId := s.currentSessionId()
+
+	byteBody, err := s.send(ctx, sessionId, input)
+	if err == nil || !errors.Is(err, erros.ErrSessaoInvalida) || !s.canRelogin() {
+		return byteBody, err
+	}
+
+	if err := s.relogin(ctx, sessionId); err != nil {
 		return nil, err
 	}
 
-	sess := Session{
-		SessionId:      loginResponse.Header.SessionId,
-		Endpoint:       input.Endpoint,
-		NomeImob:       *loginResponse.Body.NomeImob,
-		ImobId:         input.ImobId,
-		UsuarioId:      *loginResponse.Body.UsuarioId,
-		Nome:           *loginResponse.Body.Nome,
-		Versao:         *loginResponse.Body.Versao,
-		ClientIP:       *loginResponse.Body.ClientIP,
-		CodFilial:      *loginResponse.Body.CodFilial,
-		NomeFilial:     *loginResponse.Body.NomeFilial,
-		Cidade:         *loginResponse.Body.Cidade,
-		Uf:             *loginResponse.Body.Uf,
-		MaxSessions:    *loginResponse.Body.MaxSessions,
-		ServerDateTime: *loginResponse.Body.ServerDateTime,
+	return s.send(ctx, s.currentSessionId(), input)
+}
+
+func (s *Session) send(ctx context.Context, sessionId string, input *SendInput) ([]byte, error) {
+	input.Request.SetSessionId(sessionId)
+
+	return s.Client.Send(ctx, &transport.SendInput{
+		Endpoint:   s.Endpoint,
+		Action:     input.Action,
+		ImobId:     s.ImobId,
+		Idempotent: input.Idempotent,
+		Request:    input.Request,
+	})
+}
+
+func (s *Session) currentSessionId() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.SessionId
+}
+
+func (s *Session) canRelogin() bool {
+	return !s.disableRelogin && s.userId != "" && s.userPass != ""
+}
+
+// relogin faz um novo login, a menos que outra chamada concorrente já tenha
+// renovado a sessão expirada.
+func (s *Session) relogin(ctx context.Context, expiredSessionId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.SessionId != expiredSessionId {
+		return nil
 	}
 
-	return &sess, nil
+	return s.login(ctx)
 }
 
 func (s *Session) EndSession() error {
-	if s == nil || s.SessionId == "" {
+	return s.EndSessionContext(context.Background())
+}
+
+func (s *Session) EndSessionContext(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	sessionId := s.currentSessionId()
+	if sessionId == "" {
 		return nil
 	}
 
-	_, err := logout.Run(&logout.RunInput{
+	_, err := logout.RunContext(ctx, &logout.RunInput{
 		Endpoint:  s.Endpoint,
-		SessionId: s.SessionId,
+		Client:    s.Client,
+		SessionId: sessionId,
 	})
 	if err != nil {
 		return err
@@ -92,3 +205,8 @@ func (s *Session) EndSession() error {
 
 	return nil
 }
+
+// hashPass retorna o hash MD5 da senha, como enviado no LOGIN.
+func hashPass(pass string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.ToUpper(pass))))
+}
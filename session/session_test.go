@@ -0,0 +1,57 @@
+package session_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+	"github.com/itispx/goimobiliar/session"
+	"github.com/itispx/goimobiliar/transport"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLoginRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		retry     *transport.RetryPolicy
+		wantCalls int32
+	}{
+		{"não repetido por padrão", &transport.RetryPolicy{MaxAttempts: 3}, 1},
+		{"repetido com RetryMutating", &transport.RetryPolicy{MaxAttempts: 3, RetryMutating: true}, 3},
+	}
+
+	for _, tt := range tests {
+		var calls atomic.Int32
+		unavailable := doerFunc(func(*http.Request) (*http.Response, error) {
+			calls.Add(1)
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+		})
+
+		tt.retry.BaseDelay = time.Millisecond
+
+		_, err := session.NewSession(&session.NewInput{
+			Endpoint: "http://imobiliar.test",
+			ImobId:   "IMOB",
+			UserId:   "USUARIO",
+			UserPass: "SENHA",
+			Client:   transport.New(&transport.NewInput{HTTPClient: unavailable, Retry: tt.retry}),
+		})
+		if !errors.Is(err, erros.ErrTransiente) {
+			t.Errorf("%s: erro = %v, esperado ErrTransiente", tt.name, err)
+		}
+
+		if got := calls.Load(); got != tt.wantCalls {
+			t.Errorf("%s: %d tentativas de LOGIN, esperado %d", tt.name, got, tt.wantCalls)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+)
+
+// RateLimit limita as requisições por endpoint com um token bucket.
+type RateLimit struct {
+	RequestsPerSecond float64 // Taxa média de requisições por segundo.
+	Burst             int     // Requisições permitidas de uma vez. Valor default é 1.
+}
+
+// CircuitBreaker interrompe as chamadas a um endpoint após FailureThreshold
+// falhas transitórias consecutivas. Enquanto aberto, as chamadas falham na hora
+// com *CircuitOpenError. Após CoolDown, uma chamada de teste é liberada: se
+// tiver sucesso o circuito fecha, senão abre novamente.
+type CircuitBreaker struct {
+	FailureThreshold int           // Falhas consecutivas para abrir o circuito.
+	CoolDown         time.Duration // Tempo com o circuito aberto antes da chamada de teste.
+}
+
+// CircuitOpenError é retornado enquanto o circuito de um endpoint está aberto.
+type CircuitOpenError struct {
+	Endpoint string
+	Until    time.Time // Fim do período de espera.
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("imobiliar: circuito aberto para %s até %s", e.Endpoint, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return erros.ErrCircuitoAberto
+}
+
+type endpointState struct {
+	limiter *tokenBucket
+	breaker *breakerState
+}
+
+// endpoint retorna o estado de limite e circuito do endpoint, criado no primeiro uso.
+func (c *Client) endpoint(endpoint string) *endpointState {
+	if c.RateLimit == nil && c.CircuitBreaker == nil {
+		return nil
+	}
+
+	if state, ok := c.endpoints.Load(endpoint); ok {
+		return state.(*endpointState)
+	}
+
+	state := &endpointState{}
+	if c.RateLimit != nil && c.RateLimit.RequestsPerSecond > 0 {
+		state.limiter = newTokenBucket(c.RateLimit)
+	}
+	if c.CircuitBreaker != nil && c.CircuitBreaker.FailureThreshold > 0 {
+		state.breaker = &breakerState{policy: c.CircuitBreaker}
+	}
+
+	actual, _ := c.endpoints.LoadOrStore(endpoint, state)
+
+	return actual.(*endpointState)
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit *RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait aguarda até haver um token disponível ou ctx ser cancelado.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+type breakerState struct {
+	policy *CircuitBreaker
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool // Chamada de teste em andamento após o CoolDown.
+}
+
+func (b *breakerState) allow(endpoint string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+
+	if time.Now().Before(b.openUntil) || b.probing {
+		return &CircuitOpenError{Endpoint: endpoint, Until: b.openUntil}
+	}
+
+	b.probing = true
+
+	return nil
+}
+
+func (b *breakerState) record(failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.probing || b.failures >= b.policy.FailureThreshold {
+		b.openUntil = time.Now().Add(b.policy.CoolDown)
+		b.probing = false
+	}
+}
+
+// release libera a chamada de teste sem alterar o estado do circuito.
+func (b *breakerState) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
@@ -0,0 +1,172 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+)
+
+func TestBreakerState(t *testing.T) {
+	type step struct {
+		wait     time.Duration // Espera antes do passo.
+		record   *bool         // Resultado registrado; nil apenas consulta allow.
+		wantOpen bool          // Se allow deve recusar a chamada.
+	}
+
+	falha, sucesso := true, false
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"fecha abaixo do limite", []step{
+			{record: &falha},
+			{record: &falha},
+			{wantOpen: false},
+		}},
+		{"abre no limite", []step{
+			{record: &falha},
+			{record: &falha},
+			{record: &falha},
+			{wantOpen: true},
+		}},
+		{"sucesso zera as falhas", []step{
+			{record: &falha},
+			{record: &falha},
+			{record: &sucesso},
+			{record: &falha},
+			{record: &falha},
+			{wantOpen: false},
+		}},
+		{"chamada de teste com sucesso fecha", []step{
+			{record: &falha},
+			{record: &falha},
+			{record: &falha},
+			{wantOpen: true},
+			{wait: 30 * time.Millisecond, wantOpen: false},
+			{wantOpen: true}, // Apenas uma chamada de teste por vez.
+			{record: &sucesso},
+			{wantOpen: false},
+			{wantOpen: false},
+		}},
+		{"chamada de teste com falha reabre", []step{
+			{record: &falha},
+			{record: &falha},
+			{record: &falha},
+			{wait: 30 * time.Millisecond, wantOpen: false},
+			{record: &falha},
+			{wantOpen: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		b := &breakerState{policy: &CircuitBreaker{FailureThreshold: 3, CoolDown: 20 * time.Millisecond}}
+
+		for i, s := range tt.steps {
+			time.Sleep(s.wait)
+
+			if s.record != nil {
+				b.record(*s.record)
+				continue
+			}
+
+			err := b.allow("http://imobiliar.test")
+			if got := err != nil; got != s.wantOpen {
+				t.Errorf("%s: passo %d: allow = %v, esperado aberto = %v", tt.name, i, err, s.wantOpen)
+			}
+		}
+	}
+}
+
+func TestBreakerRelease(t *testing.T) {
+	b := &breakerState{policy: &CircuitBreaker{FailureThreshold: 1, CoolDown: time.Millisecond}}
+	b.record(true)
+	time.Sleep(5 * time.Millisecond)
+
+	if err := b.allow(""); err != nil {
+		t.Fatalf("chamada de teste recusada: %v", err)
+	}
+
+	b.release()
+
+	if err := b.allow(""); err != nil {
+		t.Errorf("chamada de teste recusada após release: %v", err)
+	}
+}
+
+func TestSendCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+
+	client := New(&NewInput{
+		HTTPClient:     statusDoer(&calls, 503, 503, 200),
+		Retry:          NoRetry,
+		CircuitBreaker: &CircuitBreaker{FailureThreshold: 2, CoolDown: 20 * time.Millisecond},
+	})
+
+	send := func() error {
+		_, err := client.Send(context.Background(), &SendInput{Endpoint: "http://imobiliar.test", Idempotent: true, Request: map[string]any{}})
+		return err
+	}
+
+	tests := []struct {
+		wait      time.Duration
+		wantErr   error
+		wantCalls int32
+	}{
+		{0, erros.ErrTransiente, 1},
+		{0, erros.ErrTransiente, 2},
+		{0, erros.ErrCircuitoAberto, 2},
+		{30 * time.Millisecond, nil, 3},
+		{0, nil, 4},
+	}
+
+	for i, tt := range tests {
+		time.Sleep(tt.wait)
+
+		err := send()
+		if (tt.wantErr == nil && err != nil) || !errors.Is(err, tt.wantErr) {
+			t.Errorf("chamada %d: erro = %v, esperado %v", i, err, tt.wantErr)
+		}
+
+		if got := calls.Load(); got != tt.wantCalls {
+			t.Errorf("chamada %d: %d requisições, esperado %d", i, got, tt.wantCalls)
+		}
+	}
+
+	var open *CircuitOpenError
+	client.endpoint("http://imobiliar.test").breaker.record(true)
+	client.endpoint("http://imobiliar.test").breaker.record(true)
+	if err := send(); !errors.As(err, &open) || open.Endpoint != "http://imobiliar.test" {
+		t.Errorf("erro = %v, esperado *CircuitOpenError", err)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(&RateLimit{RequestsPerSecond: 50, Burst: 2})
+
+	start := time.Now()
+	for range 4 {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Duas de uma vez e mais duas a 50 por segundo: ao menos 40ms.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("4 requisições em %s, esperado ao menos 40ms", elapsed)
+	}
+
+	slow := newTokenBucket(&RateLimit{RequestsPerSecond: 0.1})
+	_ = slow.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := slow.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("erro = %v, esperado context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"context"
+	"log/slog"
+)
+
+type LoggingInput struct {
+	Logger        *slog.Logger // Valor default é slog.Default().
+	Level         slog.Level   // Nível das chamadas bem-sucedidas. Falhas são registradas em slog.LevelError.
+	LogBodies     bool         // Inclui a requisição e a resposta, com os campos sensíveis redigidos.
+	RedactCpfCnpj bool         // Também redige CPF e CNPJ (CpfCnpjFields).
+	RedactFields  []string     // Campos adicionais a redigir.
+}
+
+// Logging retorna um middleware que registra cada chamada com action, ImobId,
+// endpoint, tentativa, duração, status HTTP e erro.
+func Logging(input *LoggingInput) Middleware {
+	if input == nil {
+		input = &LoggingInput{}
+	}
+
+	fields := append([]string{}, SecretFields...)
+	if input.RedactCpfCnpj {
+		fields = append(fields, CpfCnpjFields...)
+	}
+	fields = append(fields, input.RedactFields...)
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+
+			logger := input.Logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+
+			level := input.Level
+			if err != nil {
+				level = slog.LevelError
+			}
+
+			if !logger.Enabled(ctx, level) {
+				return err
+			}
+
+			attrs := []slog.Attr{
+				slog.String("action", call.Action),
+				slog.String("imobId", call.ImobId),
+				slog.String("endpoint", call.Endpoint),
+				slog.Int("attempt", call.Attempt),
+				slog.Duration("duration", call.Duration),
+				slog.Int("status", call.StatusCode),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			if input.LogBodies {
+				attrs = append(attrs,
+					slog.String("request", string(Redact(call.Request, fields))),
+					slog.String("response", string(Redact(call.Response, fields))),
+				)
+			}
+
+			logger.LogAttrs(ctx, level, "imobiliar", attrs...)
+
+			return err
+		}
+	}
+}
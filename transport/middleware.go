@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Call descreve uma tentativa de chamada ao webservice, vista pelos middlewares.
+type Call struct {
+	Endpoint   string        // Endereço do webservice.
+	Action     string        // Nome da action enviada.
+	ImobId     string        // Identificação da administradora, quando conhecida.
+	Idempotent bool          // Indica se a action pode ser repetida sem efeitos colaterais.
+	Attempt    int           // Número da tentativa, começando em 1.
+	Header     http.Header   // Cabeçalhos HTTP adicionais da requisição.
+	Request    []byte        // Envelope da requisição serializado em JSON.
+	Response   []byte        // Corpo bruto da resposta, preenchido após a chamada.
+	StatusCode int           // Status HTTP da resposta, preenchido após a chamada.
+	Duration   time.Duration // Tempo da requisição HTTP, preenchido após a chamada.
+}
+
+// Handler executa uma chamada. O erro retornado é o mesmo devolvido pela action.
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware envolve um Handler para observar ou alterar as chamadas, por
+// exemplo para logs, métricas, auditoria ou cabeçalhos adicionais.
+type Middleware func(next Handler) Handler
+
+// Use registra middlewares no cliente. O primeiro registrado é o mais externo.
+// Deve ser chamado antes de o cliente ser usado.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+}
+
+func (c *Client) handler() Handler {
+	h := c.do
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		h = c.Middlewares[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,68 @@
+package transport
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+const Redacted = "***"
+
+// SecretFields são os campos sensíveis conhecidos do webservice.
+var SecretFields = []string{
+	"USER_PASS",        // LOGIN
+	"SessionId",        // Header de todas as actions
+	"Senha",            // cadastro_dadosconexao_*
+	"SenhaInternet",    // cadastro_pessoa_*
+	"SenhaInternetMD5", // cadastro_pessoa_consultar
+	"SenhaAdmCondom",   // locacao_imovel_*
+}
+
+// CpfCnpjFields são os campos de documentos pessoais, redigidos quando configurado.
+var CpfCnpjFields = []string{
+	"CpfCnpj",
+	"CpfCnpjPagador",
+	"CpfCnpjBeneficiario",
+	"CpfCnpjLocat",
+	"CPFSindico",
+	"CNPJ",
+	"Cnpj",
+	"FilialCnpj",
+	"CPF",
+}
+
+// Redact substitui por Redacted os valores dos campos informados em qualquer
+// nível do JSON. Se data não for um JSON válido, é retornado sem alterações.
+func Redact(data []byte, fields []string) []byte {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return data
+	}
+
+	redacted, err := json.Marshal(redactValue(value, fields))
+	if err != nil {
+		return data
+	}
+
+	return redacted
+}
+
+func redactValue(value any, fields []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if slices.Contains(fields, key) {
+				if item != nil && item != "" {
+					v[key] = Redacted
+				}
+				continue
+			}
+			v[key] = redactValue(item, fields)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, fields)
+		}
+	}
+
+	return value
+}
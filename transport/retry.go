@@ -0,0 +1,84 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+)
+
+// RetryPolicy define como repetir chamadas que falharam com erros.ErrTransiente.
+// Actions idempotentes (consultas, pesquisas, listagens e relatórios) são
+// repetidas automaticamente. Actions que alteram dados só são repetidas se
+// RetryMutating for verdadeiro, pois a primeira tentativa pode ter sido
+// processada pelo servidor.
+type RetryPolicy struct {
+	MaxAttempts   int           // Total de tentativas, incluindo a primeira.
+	BaseDelay     time.Duration // Espera antes da segunda tentativa. Dobra a cada nova tentativa.
+	MaxDelay      time.Duration // Espera máxima entre tentativas.
+	RetryMutating bool          // Permite repetir actions não idempotentes (INCLUIR, ALTERAR, EXCLUIR, QUITAR...).
+}
+
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// NoRetry desativa as novas tentativas.
+var NoRetry = &RetryPolicy{MaxAttempts: 1}
+
+func (p *RetryPolicy) attempts(idempotent bool) int {
+	if p == nil || p.MaxAttempts <= 1 {
+		return 1
+	}
+
+	if !idempotent && !p.RetryMutating {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// delay retorna a espera antes da tentativa seguinte a attempt (começando em 1),
+// com backoff exponencial e jitter: metade fixa e metade aleatória.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	if d <= 0 {
+		return 0
+	}
+
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			d = p.MaxDelay
+			break
+		}
+	}
+
+	half := d / 2
+
+	return half + rand.N(half+1)
+}
+
+func retryable(err error) bool {
+	return errors.Is(err, erros.ErrTransiente)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
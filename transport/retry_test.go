@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+)
+
+// doerFunc responde às requisições com a função informada.
+type doerFunc func(r *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// statusDoer responde com os status informados, um por chamada, repetindo o
+// último. Status 200 respondem com um envelope sem erro.
+func statusDoer(calls *atomic.Int32, status ...int) Doer {
+	return doerFunc(func(r *http.Request) (*http.Response, error) {
+		n := int(calls.Add(1))
+		code := status[min(n, len(status))-1]
+
+		body := `{"Header":{"Error":false},"Body":{}}`
+		if code != http.StatusOK {
+			body = "falha"
+		}
+
+		return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+}
+
+func TestRetryPolicyAttempts(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *RetryPolicy
+		idempotent bool
+		want       int
+	}{
+		{"nil", nil, true, 1},
+		{"NoRetry", NoRetry, true, 1},
+		{"idempotente", &RetryPolicy{MaxAttempts: 3}, true, 3},
+		{"não idempotente", &RetryPolicy{MaxAttempts: 3}, false, 1},
+		{"RetryMutating", &RetryPolicy{MaxAttempts: 3, RetryMutating: true}, false, 3},
+		{"MaxAttempts 0", &RetryPolicy{}, true, 1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.policy.attempts(tt.idempotent); got != tt.want {
+			t.Errorf("%s: attempts = %d, esperado %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 500 * time.Millisecond}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{4, 250 * time.Millisecond, 500 * time.Millisecond},
+		{10, 250 * time.Millisecond, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			if got := policy.delay(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("delay(%d) = %s, esperado entre %s e %s", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+
+	if got := (&RetryPolicy{}).delay(1); got != 0 {
+		t.Errorf("delay sem BaseDelay = %s, esperado 0", got)
+	}
+}
+
+func TestSendRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     []int
+		idempotent bool
+		retry      *RetryPolicy
+		wantCalls  int32
+		wantErr    error
+	}{
+		{"sucesso", []int{200}, true, nil, 1, nil},
+		{"transiente e sucesso", []int{503, 200}, true, nil, 2, nil},
+		{"transiente até o limite", []int{503}, true, nil, 3, erros.ErrTransiente},
+		{"429 é transiente", []int{429, 200}, true, nil, 2, nil},
+		{"4xx não é repetido", []int{400, 200}, true, nil, 1, erros.ErrRespostaInvalida},
+		{"não idempotente", []int{503, 200}, false, nil, 1, erros.ErrTransiente},
+		{"RetryMutating", []int{503, 200}, false, &RetryPolicy{MaxAttempts: 3, RetryMutating: true}, 2, nil},
+		{"NoRetry", []int{503, 200}, true, NoRetry, 1, erros.ErrTransiente},
+	}
+
+	for _, tt := range tests {
+		var calls atomic.Int32
+
+		retry := tt.retry
+		if retry == nil {
+			retry = &RetryPolicy{MaxAttempts: 3}
+		}
+
+		client := New(&NewInput{HTTPClient: statusDoer(&calls, tt.status...), Retry: retry})
+
+		_, err := client.Send(context.Background(), &SendInput{
+			Endpoint:   "http://imobiliar.test",
+			Action:     "TESTE",
+			Idempotent: tt.idempotent,
+			Request:    map[string]any{},
+		})
+
+		if got := calls.Load(); got != tt.wantCalls {
+			t.Errorf("%s: %d chamadas, esperado %d", tt.name, got, tt.wantCalls)
+		}
+
+		switch {
+		case tt.wantErr == nil && err != nil:
+			t.Errorf("%s: erro inesperado: %v", tt.name, err)
+		case !errors.Is(err, tt.wantErr):
+			t.Errorf("%s: erro = %v, esperado %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSendRetryCancelado(t *testing.T) {
+	var calls atomic.Int32
+
+	client := New(&NewInput{
+		HTTPClient: statusDoer(&calls, 503),
+		Retry:      &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Send(ctx, &SendInput{Endpoint: "http://imobiliar.test", Idempotent: true, Request: map[string]any{}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("erro = %v, esperado context.DeadlineExceeded", err)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("%d chamadas, esperado 1", got)
+	}
+}
@@ -0,0 +1,237 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/itispx/goimobiliar/erros"
+)
+
+const DefaultTimeout = 60 * time.Second
+
+// Doer é a interface mínima de um cliente HTTP. *http.Client a implementa.
+type Doer interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// Client centraliza o envio das requisições ao webservice Imobiliar.
+// Todas as actions, incluindo LOGIN e LOGOUT, passam por ele.
+type Client struct {
+	HTTPClient     Doer            // Cliente HTTP usado nas chamadas.
+	Retry          *RetryPolicy    // Política de novas tentativas. Se nil, não há novas tentativas.
+	RateLimit      *RateLimit      // Limite de requisições por endpoint. Se nil, não há limite.
+	CircuitBreaker *CircuitBreaker // Circuito por endpoint. Se nil, não há circuito.
+	Middlewares    []Middleware    // Middlewares aplicados a cada tentativa. O primeiro é o mais externo.
+
+	endpoints sync.Map // Endpoint -> *endpointState.
+}
+
+type NewInput struct {
+	HTTPClient Doer                                  // Cliente HTTP próprio. Se informado, Timeout, Proxy e TLSConfig são ignorados.
+	Timeout    time.Duration                         // Tempo máximo de cada requisição. Valor default é DefaultTimeout.
+	Proxy      func(*http.Request) (*url.URL, error) // Proxy das requisições. Valor default é http.ProxyFromEnvironment.
+	TLSConfig  *tls.Config                           // Configuração TLS para bases com certificados próprios.
+	Retry      *RetryPolicy                          // Política de novas tentativas. Valor default é DefaultRetryPolicy; use NoRetry para desativar.
+
+	RateLimit      *RateLimit      // Opcional. Limite de requisições por endpoint.
+	CircuitBreaker *CircuitBreaker // Opcional. Circuito por endpoint.
+	Middlewares    []Middleware    // Opcional. Middlewares aplicados a cada tentativa.
+}
+
+// DefaultClient é usado sempre que nenhum Client é informado na sessão.
+var DefaultClient = New(&NewInput{})
+
+func New(input *NewInput) *Client {
+	if input == nil {
+		input = &NewInput{}
+	}
+
+	retry := input.Retry
+	if retry == nil {
+		retry = DefaultRetryPolicy
+	}
+
+	if input.HTTPClient != nil {
+		return &Client{
+			HTTPClient:     input.HTTPClient,
+			Retry:          retry,
+			RateLimit:      input.RateLimit,
+			CircuitBreaker: input.CircuitBreaker,
+			Middlewares:    input.Middlewares,
+		}
+	}
+
+	timeout := input.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	proxy := input.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = proxy
+	tr.TLSClientConfig = input.TLSConfig
+	tr.MaxIdleConnsPerHost = 16
+
+	return &Client{
+		HTTPClient: &http.Client{
+			Timeout:   timeout,
+			Transport: tr,
+		},
+		Retry:          retry,
+		RateLimit:      input.RateLimit,
+		CircuitBreaker: input.CircuitBreaker,
+		Middlewares:    input.Middlewares,
+	}
+}
+
+type SendInput struct {
+	Endpoint   string // Endereço do webservice.
+	Action     string // Nome da action enviada.
+	ImobId     string // Identificação da administradora, usada em logs e métricas.
+	Idempotent bool   // Indica se a action pode ser repetida sem efeitos colaterais.
+	Request    any    // Envelope da requisição (Header e Body), serializado em JSON.
+}
+
+// Send serializa o envelope, envia ao webservice e devolve o corpo da resposta
+// já verificado por erros.CheckResponseError. Falhas transitórias são repetidas
+// conforme a política de Retry do cliente.
+func (c *Client) Send(ctx context.Context, input *SendInput) ([]byte, error) {
+	if c == nil {
+		c = DefaultClient
+	}
+
+	data, err := json.Marshal(input.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := c.Retry.attempts(input.Idempotent)
+	state := c.endpoint(input.Endpoint)
+	handler := c.handler()
+
+	for attempt := 1; ; attempt++ {
+		call := &Call{
+			Endpoint:   input.Endpoint,
+			Action:     input.Action,
+			ImobId:     input.ImobId,
+			Idempotent: input.Idempotent,
+			Attempt:    attempt,
+			Header:     http.Header{},
+			Request:    data,
+		}
+
+		err := c.sendLimited(ctx, state, handler, call)
+		if err == nil {
+			return call.Response, nil
+		}
+
+		if attempt >= attempts || !retryable(err) {
+			return nil, err
+		}
+
+		if err := sleep(ctx, c.Retry.delay(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendLimited aplica o circuito e o limite de requisições do endpoint a uma
+// única tentativa.
+func (c *Client) sendLimited(ctx context.Context, state *endpointState, handler Handler, call *Call) error {
+	if state == nil {
+		return handler(ctx, call)
+	}
+
+	if err := state.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	if err := state.breaker.allow(call.Endpoint); err != nil {
+		return err
+	}
+
+	err := handler(ctx, call)
+	if ctx.Err() != nil {
+		// Cancelamento não indica a saúde do endpoint.
+		state.breaker.release()
+	} else {
+		state.breaker.record(retryable(err))
+	}
+
+	return err
+}
+
+// do é o Handler mais interno: faz a requisição HTTP e verifica a resposta.
+func (c *Client) do(ctx context.Context, call *Call) error {
+	r, err := http.NewRequestWithContext(ctx, "POST", call.Endpoint, bytes.NewBuffer(call.Request))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range call.Header {
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = DefaultClient.HTTPClient
+	}
+
+	start := time.Now()
+	defer func() {
+		call.Duration = time.Since(start)
+	}()
+
+	res, err := httpClient.Do(r)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		return erros.Transiente(err)
+	}
+	defer res.Body.Close()
+
+	call.StatusCode = res.StatusCode
+
+	byteBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return erros.Transiente(err)
+	}
+
+	call.Response = byteBody
+
+	if res.StatusCode >= 300 && !bytes.HasPrefix(byteBody, []byte("468")) {
+		return &erros.HTTPError{
+			StatusCode: res.StatusCode,
+			Body:       string(byteBody),
+		}
+	}
+
+	err = erros.CheckResponseError(&byteBody)
+	if err != nil {
+		var apiError *erros.APIError
+		if errors.As(err, &apiError) && apiError.Action == "" {
+			apiError.Action = call.Action
+		}
+
+		return err
+	}
+
+	return nil
+}